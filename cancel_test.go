@@ -39,6 +39,9 @@ func TestCancelButton_RenderedOnlyWhenConfigured(t *testing.T) {
 			{"Bootstrap", "bootstrap"},
 			{"TailwindV3", "tailwind"},
 			{"TailwindV4", "tailwindv4"},
+			{"Bulma", "bulma"},
+			{"Pico", "pico"},
+			{"Foundation", "foundation"},
 		}
 
 		for _, ts := range themeSets {
@@ -72,6 +75,9 @@ func TestCancelRendersForThemes(t *testing.T) {
 		{"Bootstrap", "bootstrap"},
 		{"TailwindV3", "tailwind"},
 		{"TailwindV4", "tailwindv4"},
+		{"Bulma", "bulma"},
+		{"Pico", "pico"},
+		{"Foundation", "foundation"},
 	}
 
 	for _, ts := range themeSets {
@@ -77,6 +77,9 @@ func TestClassInRenderedHTML(t *testing.T) {
 		{"Plain", "plain"},
 		{"Tailwind", "tailwind"},
 		{"TailwindV4", "tailwindv4"},
+		{"Bulma", "bulma"},
+		{"Pico", "pico"},
+		{"Foundation", "foundation"},
 	}
 
 	for _, ts := range themeSets {
@@ -3,7 +3,12 @@ package form
 import (
 	"context"
 	"errors"
+	"html/template"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/donseba/go-form/v2/csrf"
@@ -11,15 +16,75 @@ import (
 
 // CSRF errors
 var (
-	DefaultCSRFField = "_csrf"
+	DefaultCSRFField  = "_csrf"
+	DefaultCSRFHeader = "X-CSRF-Token"
 )
 
 // CSRFOptions configures how the CSRF middleware behaves
 type CSRFOptions struct {
 	// ErrorHandler lets you customize error handling instead of returning HTTP errors
 	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+	// HeaderNames are the request headers that may carry the token, for fetch, htmx
+	// and JSON requests. They are tried in order before the form body. When empty,
+	// DefaultCSRFHeader is used.
+	HeaderNames []string
+
+	// FieldName is the form field that carries the token in urlencoded and multipart
+	// bodies. When empty, DefaultCSRFField is used.
+	FieldName string
+
+	// TrustedOrigins are other origins allowed to submit, such as
+	// "https://admin.example.com". The request's own host is always allowed.
+	TrustedOrigins []string
+
+	// CheckOrigin rejects unsafe requests whose Origin header is set to another host
+	// than the request's and is not trusted, with csrf.ErrOriginMismatch. Behind a
+	// proxy that rewrites the Host header, add the public origin to TrustedOrigins.
+	CheckOrigin bool
+
+	// StrictReferer requires HTTPS requests without an Origin header to have an HTTPS
+	// Referer from the request's host or a trusted origin. It reports
+	// csrf.ErrRefererMissing or csrf.ErrRefererMismatch.
+	StrictReferer bool
+
+	// CheckFetchSite rejects unsafe requests with "Sec-Fetch-Site: cross-site" unless
+	// their Origin is trusted, with csrf.ErrCrossSiteRequest.
+	CheckFetchSite bool
+
+	// Cookie configures the session cookie that tokens are bound to.
+	Cookie CSRFCookie
+
+	// TrustForwardedProto treats requests with "X-Forwarded-Proto: https" as HTTPS,
+	// for the Secure cookie attribute and StrictReferer. Only enable it behind a proxy
+	// that sets the header, as clients can send it too.
+	TrustForwardedProto bool
+
+	// ExemptPaths are URL paths the middleware skips, such as webhooks. Patterns use
+	// path.Match syntax, e.g. "/hooks/*"; a pattern ending in "/" matches every path
+	// below it, like http.ServeMux. The request path is cleaned before matching.
+	ExemptPaths []string
+
+	// ProtectedMethods are the methods whose requests must carry a valid token. When
+	// empty, DefaultCSRFProtectedMethods is used. GET and HEAD requests that are not
+	// protected receive a new token.
+	ProtectedMethods []string
 }
 
+// CSRFCookie configures the session cookie of the CSRF middleware. The cookie is
+// always HttpOnly.
+type CSRFCookie struct {
+	Name        string        // Defaults to csrf.DefaultSessionID
+	Domain      string        // Defaults to the request's host only
+	Path        string        // Defaults to "/"
+	SameSite    http.SameSite // Defaults to http.SameSiteLaxMode
+	Secure      bool          // Always set Secure; otherwise only over HTTPS, with SameSite None or Partitioned
+	Partitioned bool          // Set Partitioned (CHIPS) for use in third-party iframes
+}
+
+// DefaultCSRFProtectedMethods are the methods the CSRF middleware protects by default.
+var DefaultCSRFProtectedMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
 // DefaultCSRFOptions returns the default options for CSRF protection
 func DefaultCSRFOptions() CSRFOptions {
 	return CSRFOptions{
@@ -29,6 +94,9 @@ func DefaultCSRFOptions() CSRFOptions {
 				http.Error(w, "Invalid CSRF token", http.StatusForbidden)
 			case errors.Is(err, csrf.ErrTokenExpired):
 				http.Error(w, "CSRF token expired", http.StatusForbidden)
+			case errors.Is(err, csrf.ErrOriginMismatch), errors.Is(err, csrf.ErrRefererMismatch),
+				errors.Is(err, csrf.ErrRefererMissing), errors.Is(err, csrf.ErrCrossSiteRequest):
+				http.Error(w, "Cross-site request rejected", http.StatusForbidden)
 			case errors.Is(err, csrf.ErrKeyOrTokenEmpty):
 				http.Error(w, "CSRF token or session ID is empty", http.StatusBadRequest)
 			case errors.Is(err, csrf.ErrTokenNotFound):
@@ -40,6 +108,14 @@ func DefaultCSRFOptions() CSRFOptions {
 	}
 }
 
+// newDefaultCSRFStore returns the store of a new Form: every session keeps its
+// recent tokens, so forms in several tabs can be submitted. A token can be submitted
+// until it expires, so pages that send it with every fetch or htmx request keep
+// working; use a MultiTokenStore with OneTime for single-use tokens.
+func newDefaultCSRFStore() csrf.Store {
+	return csrf.NewMultiTokenStore(csrf.MultiTokenOptions{})
+}
+
 // CSRFMiddleware creates middleware for CSRF protection with default options
 func (f *Form) CSRFMiddleware() func(next http.Handler) http.Handler {
 	return f.CSRFMiddlewareWithOptions(DefaultCSRFOptions())
@@ -48,13 +124,36 @@ func (f *Form) CSRFMiddleware() func(next http.Handler) http.Handler {
 // CSRFMiddlewareWithOptions creates middleware for CSRF protection with custom options
 func (f *Form) CSRFMiddlewareWithOptions(options CSRFOptions) func(next http.Handler) http.Handler {
 	if !f.HasCSRFStore() {
-		f.SetCSRFStore(csrf.NewMemoryCSRFStore())
+		f.SetCSRFStore(newDefaultCSRFStore())
+	}
+
+	trusted := make(map[string]bool, len(options.TrustedOrigins))
+	for _, origin := range options.TrustedOrigins {
+		trusted[normalizeOrigin(origin)] = true
+	}
+	for _, pattern := range options.ExemptPaths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic("form: invalid CSRF exempt path " + strconv.Quote(pattern))
+		}
+	}
+	methods := options.ProtectedMethods
+	if len(methods) == 0 {
+		methods = DefaultCSRFProtectedMethods
+	}
+	protected := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		protected[strings.ToUpper(method)] = true
 	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if csrfExempt(r.URL.Path, options.ExemptPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			// Get session key (from cookie or create one)
-			sessionID, err := getOrCreateSessionID(w, r)
+			sessionID, err := getOrCreateSessionID(w, r, options)
 			if err != nil {
 				if options.ErrorHandler != nil {
 					options.ErrorHandler(w, r, err)
@@ -68,41 +167,39 @@ func (f *Form) CSRFMiddlewareWithOptions(options CSRFOptions) func(next http.Han
 			ctx := context.WithValue(r.Context(), csrf.SessionIDContextKey, sessionID)
 			r = r.WithContext(ctx)
 
-			// For safe methods (GET, HEAD), generate and store a token
-			if r.Method == http.MethodGet || r.Method == http.MethodHead {
-				token, err := csrf.GenerateCSRFToken()
+			// For safe methods (GET, HEAD), provide a token
+			if !protected[r.Method] && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				token, err := safeCSRFToken(f.GetCSRFStore(), sessionID, csrfTokenFromRequest(r, options))
 				if err != nil {
 					if options.ErrorHandler != nil {
 						options.ErrorHandler(w, r, err)
 						return
 					}
-					http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
+					http.Error(w, "Failed to create CSRF token", http.StatusInternalServerError)
 					return
 				}
 
-				// Store the token
-				err = f.GetCSRFStore().Store(sessionID, token)
-				if err != nil {
+				// Add token to context
+				ctx = context.WithValue(r.Context(), csrf.CSRFTokenContextKey, token)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			// For protected methods, validate the token
+			if protected[r.Method] {
+				if err := checkRequestOrigin(r, options, trusted); err != nil {
 					if options.ErrorHandler != nil {
 						options.ErrorHandler(w, r, err)
 						return
 					}
-					http.Error(w, "Failed to store CSRF token", http.StatusInternalServerError)
+					http.Error(w, "Cross-site request rejected", http.StatusForbidden)
 					return
 				}
 
-				// Add token to context
-				ctx = context.WithValue(r.Context(), csrf.CSRFTokenContextKey, token)
-				next.ServeHTTP(w, r.WithContext(ctx))
-				return
-			}
-
-			// For unsafe methods, validate the token
-			if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodDelete || r.Method == http.MethodPatch {
-				submittedToken := r.FormValue(DefaultCSRFField)
+				submittedToken := csrfTokenFromRequest(r, options)
 				if submittedToken == "" {
 					if options.ErrorHandler != nil {
-						options.ErrorHandler(w, r, errors.New("token not found"))
+						options.ErrorHandler(w, r, csrf.ErrTokenNotFound)
 						return
 					}
 					http.Error(w, "Missing CSRF token", http.StatusBadRequest)
@@ -125,24 +222,13 @@ func (f *Form) CSRFMiddlewareWithOptions(options CSRFOptions) func(next http.Han
 				}
 
 				// Generate a fresh token for the next request
-				token, err := csrf.GenerateCSRFToken()
-				if err != nil {
-					if options.ErrorHandler != nil {
-						options.ErrorHandler(w, r, err)
-						return
-					}
-					http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
-					return
-				}
-
-				// Store the token
-				err = f.GetCSRFStore().Store(sessionID, token)
+				token, err := issueCSRFToken(f.GetCSRFStore(), sessionID)
 				if err != nil {
 					if options.ErrorHandler != nil {
 						options.ErrorHandler(w, r, err)
 						return
 					}
-					http.Error(w, "Failed to store CSRF token", http.StatusInternalServerError)
+					http.Error(w, "Failed to create CSRF token", http.StatusInternalServerError)
 					return
 				}
 
@@ -158,10 +244,156 @@ func (f *Form) CSRFMiddlewareWithOptions(options CSRFOptions) func(next http.Han
 	}
 }
 
+// safeCSRFToken returns the token for a GET or HEAD request. A token sent along with
+// the request that the store can check and that is still valid is handed back, so
+// background requests, such as htmx polling, do not push the tokens of other tabs out
+// of the store. Otherwise a new token is issued.
+func safeCSRFToken(store csrf.Store, sessionID, submitted string) (string, error) {
+	if checker, ok := store.(csrf.TokenChecker); ok && submitted != "" && checker.Check(sessionID, submitted) == nil {
+		return submitted, nil
+	}
+	return issueCSRFToken(store, sessionID)
+}
+
+// issueCSRFToken creates a token for the session: with the store itself when it
+// generates its own tokens, otherwise as a random token saved in the store.
+func issueCSRFToken(store csrf.Store, sessionID string) (string, error) {
+	if gen, ok := store.(csrf.TokenGenerator); ok {
+		return gen.GenerateToken(sessionID)
+	}
+	token, err := csrf.GenerateCSRFToken()
+	if err != nil {
+		return "", err
+	}
+	if err := store.Store(sessionID, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// checkRequestOrigin runs the Sec-Fetch-Site, Origin and Referer checks enabled in
+// options on an unsafe request. Like the browsers' own same-origin rules for forms,
+// a request is same-origin when the host of its Origin or Referer is the request's
+// host; the scheme is not compared, so TLS terminated by a proxy does not matter.
+func checkRequestOrigin(r *http.Request, options CSRFOptions, trusted map[string]bool) error {
+	origin := r.Header.Get("Origin")
+	originTrusted := origin != "" && trusted[normalizeOrigin(origin)]
+
+	if options.CheckFetchSite && r.Header.Get("Sec-Fetch-Site") == "cross-site" && !originTrusted {
+		return csrf.ErrCrossSiteRequest
+	}
+
+	if options.CheckOrigin && origin != "" && !originTrusted {
+		u, err := url.Parse(origin)
+		if err != nil || !strings.EqualFold(u.Host, r.Host) {
+			return csrf.ErrOriginMismatch
+		}
+	}
+
+	if options.StrictReferer && isHTTPS(r, options) && origin == "" {
+		referer := r.Header.Get("Referer")
+		if referer == "" {
+			return csrf.ErrRefererMissing
+		}
+		u, err := url.Parse(referer)
+		if err != nil || u.Scheme != "https" {
+			return csrf.ErrRefererMismatch
+		}
+		if !strings.EqualFold(u.Host, r.Host) && !trusted[normalizeOrigin(u.Scheme+"://"+u.Host)] {
+			return csrf.ErrRefererMismatch
+		}
+	}
+	return nil
+}
+
+// normalizeOrigin lowercases an origin and drops a trailing slash.
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
+
+// csrfTokenFromRequest returns the submitted token: from the first configured header
+// that is set, then from the form field of a urlencoded body, then from the field of a
+// multipart body. Tokens in the query string are ignored, as URLs end up in logs.
+func csrfTokenFromRequest(r *http.Request, options CSRFOptions) string {
+	headers := options.HeaderNames
+	if len(headers) == 0 {
+		headers = []string{DefaultCSRFHeader}
+	}
+	for _, name := range headers {
+		if token := r.Header.Get(name); token != "" {
+			return token
+		}
+	}
+
+	field := options.FieldName
+	if field == "" {
+		field = DefaultCSRFField
+	}
+	if err := r.ParseForm(); err == nil {
+		if token := r.PostForm.Get(field); token != "" {
+			return token
+		}
+	}
+	if err := r.ParseMultipartForm(DefaultMultipartMemory); err == nil {
+		if values := r.MultipartForm.Value[field]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// csrfExempt reports whether urlPath matches one of the exempt path patterns. The
+// path is cleaned first, keeping a trailing slash like http.ServeMux, so dot segments
+// such as "/hooks/../admin" cannot reach a protected path through an exempt prefix.
+func csrfExempt(urlPath string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	clean := path.Clean("/" + urlPath)
+	if strings.HasSuffix(urlPath, "/") && clean != "/" {
+		clean += "/"
+	}
+	urlPath = clean
+
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") && strings.HasPrefix(urlPath, pattern) {
+			return true
+		}
+		if ok, _ := path.Match(pattern, urlPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isHTTPS reports whether the request reached the server, or with
+// TrustForwardedProto the proxy in front of it, over HTTPS.
+func isHTTPS(r *http.Request, options CSRFOptions) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !options.TrustForwardedProto {
+		return false
+	}
+	proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
+
 // Helper function to get or create a session ID
-func getOrCreateSessionID(w http.ResponseWriter, r *http.Request) (string, error) {
+func getOrCreateSessionID(w http.ResponseWriter, r *http.Request, options CSRFOptions) (string, error) {
+	c := options.Cookie
+	if c.Name == "" {
+		c.Name = csrf.DefaultSessionID
+	}
+	if c.Path == "" {
+		c.Path = "/"
+	}
+	if c.SameSite == 0 {
+		c.SameSite = http.SameSiteLaxMode
+	}
+
 	// Check for existing session cookie
-	cookie, err := r.Cookie(csrf.DefaultSessionID)
+	cookie, err := r.Cookie(c.Name)
 	if err == nil && cookie.Value != "" {
 		return cookie.Value, nil
 	}
@@ -172,15 +404,17 @@ func getOrCreateSessionID(w http.ResponseWriter, r *http.Request) (string, error
 		return "", err
 	}
 
-	// Set the cookie
+	// Set the cookie. Browsers drop SameSite=None and Partitioned cookies without Secure.
 	http.SetCookie(w, &http.Cookie{
-		Name:     csrf.DefaultSessionID,
-		Value:    sessionID,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   r.TLS != nil, // Set secure if using HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(csrf.DefaultExpirationTime),
+		Name:        c.Name,
+		Value:       sessionID,
+		Domain:      c.Domain,
+		Path:        c.Path,
+		HttpOnly:    true,
+		Secure:      c.Secure || isHTTPS(r, options) || c.SameSite == http.SameSiteNoneMode || c.Partitioned,
+		SameSite:    c.SameSite,
+		Partitioned: c.Partitioned,
+		Expires:     time.Now().Add(csrf.DefaultExpirationTime),
 	})
 
 	return sessionID, nil
@@ -229,3 +463,29 @@ func InjectCSRFTokenContext(ctx context.Context, info *Info) {
 		}
 	}
 }
+
+// CSRFMetaHTML returns a <meta name="csrf-token"> element holding the CSRF token, so
+// scripts such as htmx or fetch can send it in the DefaultCSRFHeader. v is the token,
+// the *http.Request or context.Context the middleware stored it in, or a model with
+// Info. Without a token it returns nothing. It is available as form_csrf_meta.
+func CSRFMetaHTML(v any) template.HTML {
+	var token string
+	switch t := v.(type) {
+	case string:
+		token = t
+	case *http.Request:
+		if t != nil {
+			token, _ = GetCSRFToken(t)
+		}
+	case context.Context:
+		token, _ = t.Value(csrf.CSRFTokenContextKey).(string)
+	default:
+		if info, ok := modelInfo(v); ok {
+			token = info.CsrfValue
+		}
+	}
+	if token == "" {
+		return ""
+	}
+	return template.HTML(`<meta name="csrf-token" content="` + template.HTMLEscapeString(token) + `">`)
+}
@@ -0,0 +1,117 @@
+package csrf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// MinSecretLength is the minimum length of an HMACStore secret in bytes
+const MinSecretLength = 32
+
+// HMACStore is a stateless Store. Its tokens carry a random nonce and their expiry,
+// signed with HMAC-SHA256 over the session id, so any server that shares the secret
+// can check them without storage, and they survive restarts. A token stays valid
+// until it expires, also after it was used.
+type HMACStore struct {
+	// Lifetime is how long a token is valid. It defaults to DefaultExpirationTime.
+	Lifetime time.Duration
+
+	secrets [][]byte
+	now     func() time.Time
+}
+
+const (
+	hmacNonceLength   = 16
+	hmacPayloadLength = hmacNonceLength + 8
+)
+
+// NewHMACStore creates a store that signs tokens with secret. Tokens signed with one
+// of the previous secrets are still accepted, so secrets can be rotated: put the new
+// secret first and keep the old one in previous until its tokens have expired.
+func NewHMACStore(secret []byte, previous ...[]byte) (*HMACStore, error) {
+	secrets := append([][]byte{secret}, previous...)
+	for _, s := range secrets {
+		if len(s) < MinSecretLength {
+			return nil, ErrSecretTooShort
+		}
+	}
+	return &HMACStore{
+		Lifetime: DefaultExpirationTime,
+		secrets:  secrets,
+		now:      time.Now,
+	}, nil
+}
+
+// GenerateToken returns a token for key signed with the current secret
+func (s *HMACStore) GenerateToken(key string) (string, error) {
+	if key == "" {
+		return "", ErrKeyOrTokenEmpty
+	}
+	lifetime := s.Lifetime
+	if lifetime <= 0 {
+		lifetime = DefaultExpirationTime
+	}
+
+	payload := make([]byte, hmacPayloadLength)
+	if _, err := rand.Read(payload[:hmacNonceLength]); err != nil {
+		return "", err
+	}
+	binary.BigEndian.PutUint64(payload[hmacNonceLength:], uint64(s.now().Add(lifetime).Unix()))
+
+	mac := signHMAC(s.secrets[0], key, payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac), nil
+}
+
+// Store does nothing, as the tokens of an HMACStore carry their own state
+func (s *HMACStore) Store(key, token string) error {
+	if key == "" || token == "" {
+		return ErrKeyOrTokenEmpty
+	}
+	return nil
+}
+
+// Validate checks that the token was signed for key with one of the secrets and has
+// not expired
+func (s *HMACStore) Validate(key, token string) error {
+	if key == "" || token == "" {
+		return ErrKeyOrTokenEmpty
+	}
+
+	encPayload, encMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrTokenMismatch
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encPayload)
+	if err != nil || len(payload) != hmacPayloadLength {
+		return ErrTokenMismatch
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encMAC)
+	if err != nil {
+		return ErrTokenMismatch
+	}
+
+	for _, secret := range s.secrets {
+		if !hmac.Equal(mac, signHMAC(secret, key, payload)) {
+			continue
+		}
+		expiry := time.Unix(int64(binary.BigEndian.Uint64(payload[hmacNonceLength:])), 0)
+		if s.now().After(expiry) {
+			return ErrTokenExpired
+		}
+		return nil
+	}
+	return ErrTokenMismatch
+}
+
+// signHMAC signs the fixed-length payload followed by the session id
+func signHMAC(secret []byte, key string, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	h.Write([]byte(key))
+	return h.Sum(nil)
+}
@@ -0,0 +1,79 @@
+package csrf
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHMACStore(t *testing.T) {
+	oldSecret := bytes.Repeat([]byte("o"), MinSecretLength)
+	newSecret := bytes.Repeat([]byte("n"), MinSecretLength)
+
+	old, err := NewHMACStore(oldSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, err := old.GenerateToken("session-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewHMACStore(newSecret, oldSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := store.GenerateToken("session-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == oldToken {
+		t.Fatal("tokens should differ")
+	}
+
+	// A store that shares the secret validates without storage.
+	peer, _ := NewHMACStore(newSecret)
+	if err := peer.Validate("session-a", token); err != nil {
+		t.Errorf("peer validation: %v", err)
+	}
+	if err := store.Validate("session-a", oldToken); err != nil {
+		t.Errorf("token of the previous secret: %v", err)
+	}
+	if err := peer.Validate("session-a", oldToken); !errors.Is(err, ErrTokenMismatch) {
+		t.Errorf("token of a dropped secret: %v", err)
+	}
+
+	payload, mac, _ := strings.Cut(token, ".")
+	for name, tc := range map[string]struct {
+		key, token string
+		want       error
+	}{
+		"other session":  {"session-b", token, ErrTokenMismatch},
+		"tampered":       {"session-a", payload + "." + strings.Repeat("A", len(mac)), ErrTokenMismatch},
+		"no signature":   {"session-a", payload, ErrTokenMismatch},
+		"not base64":     {"session-a", "!!." + mac, ErrTokenMismatch},
+		"empty":          {"session-a", "", ErrKeyOrTokenEmpty},
+		"random token":   {"session-a", "c29tZS10b2tlbg==", ErrTokenMismatch},
+		"empty session":  {"", token, ErrKeyOrTokenEmpty},
+		"short payload":  {"session-a", "AAAA." + mac, ErrTokenMismatch},
+		"valid mac only": {"session-a", oldToken[:strings.Index(oldToken, ".")] + "." + mac, ErrTokenMismatch},
+	} {
+		if err := store.Validate(tc.key, tc.token); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", name, err, tc.want)
+		}
+	}
+
+	store.now = func() time.Time { return time.Now().Add(DefaultExpirationTime + time.Minute) }
+	if err := store.Validate("session-a", token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("expired token: %v", err)
+	}
+
+	if _, err := NewHMACStore([]byte("short")); !errors.Is(err, ErrSecretTooShort) {
+		t.Errorf("short secret: %v", err)
+	}
+	if _, err := NewHMACStore(newSecret, []byte("short")); !errors.Is(err, ErrSecretTooShort) {
+		t.Errorf("short previous secret: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package csrf
+
+import (
+	"crypto/subtle"
+	"sync"
+	"time"
+)
+
+// MultiTokenOptions configures a MultiTokenStore
+type MultiTokenOptions struct {
+	// Size is the number of tokens kept per session. When a session has Size tokens,
+	// storing another one drops the oldest. It defaults to DefaultTokensPerSession.
+	Size int
+
+	// Lifetime is how long each token is valid. It defaults to DefaultExpirationTime.
+	Lifetime time.Duration
+
+	// OneTime removes a token once it validated, so it cannot be submitted twice
+	OneTime bool
+}
+
+// MultiTokenStore is an in-memory store that keeps the most recent tokens of every
+// session, each with its own expiry. A user with several tabs open can submit the
+// form of every tab, as long as it is one of the last Size tokens of the session.
+type MultiTokenStore struct {
+	mu        sync.Mutex
+	sessions  map[string]*tokenRing
+	size      int
+	lifetime  time.Duration
+	oneTime   bool
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// tokenRing holds the tokens of a session, overwriting the oldest when full
+type tokenRing struct {
+	entries []TokenEntry
+	next    int
+}
+
+// NewMultiTokenStore creates a MultiTokenStore
+func NewMultiTokenStore(opts MultiTokenOptions) *MultiTokenStore {
+	if opts.Size <= 0 {
+		opts.Size = DefaultTokensPerSession
+	}
+	if opts.Lifetime <= 0 {
+		opts.Lifetime = DefaultExpirationTime
+	}
+	return &MultiTokenStore{
+		sessions: make(map[string]*tokenRing),
+		size:     opts.Size,
+		lifetime: opts.Lifetime,
+		oneTime:  opts.OneTime,
+		now:      time.Now,
+	}
+}
+
+// Store adds a token to the session, dropping its oldest token when it is full
+func (s *MultiTokenStore) Store(key, token string) error {
+	if key == "" || token == "" {
+		return ErrKeyOrTokenEmpty
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweepLocked(now)
+
+	ring := s.sessions[key]
+	if ring == nil {
+		ring = &tokenRing{entries: make([]TokenEntry, s.size)}
+		s.sessions[key] = ring
+	}
+	ring.entries[ring.next] = TokenEntry{Token: token, Expiration: now.Add(s.lifetime)}
+	ring.next = (ring.next + 1) % len(ring.entries)
+	return nil
+}
+
+// Validate checks that the token is one of the session's tokens and has not expired.
+// With OneTime the token is removed.
+func (s *MultiTokenStore) Validate(key, token string) error {
+	return s.validate(key, token, s.oneTime)
+}
+
+// Check is Validate without removing a one-time token
+func (s *MultiTokenStore) Check(key, token string) error {
+	return s.validate(key, token, false)
+}
+
+func (s *MultiTokenStore) validate(key, token string, use bool) error {
+	if key == "" || token == "" {
+		return ErrKeyOrTokenEmpty
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ring := s.sessions[key]
+	if ring == nil {
+		return ErrTokenNotFound
+	}
+
+	for i, entry := range ring.entries {
+		if entry.Token == "" || subtle.ConstantTimeCompare([]byte(entry.Token), []byte(token)) != 1 {
+			continue
+		}
+		if s.now().After(entry.Expiration) {
+			ring.entries[i] = TokenEntry{}
+			return ErrTokenExpired
+		}
+		if use {
+			ring.entries[i] = TokenEntry{}
+		}
+		return nil
+	}
+	return ErrTokenMismatch
+}
+
+// sweepLocked removes the sessions whose tokens have all expired, at most once per
+// DefaultCleanupIntervalTime
+func (s *MultiTokenStore) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < DefaultCleanupIntervalTime {
+		return
+	}
+	s.lastSweep = now
+	for key, ring := range s.sessions {
+		live := false
+		for _, entry := range ring.entries {
+			if entry.Token != "" && !now.After(entry.Expiration) {
+				live = true
+				break
+			}
+		}
+		if !live {
+			delete(s.sessions, key)
+		}
+	}
+}
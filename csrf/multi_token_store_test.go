@@ -0,0 +1,76 @@
+package csrf
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMultiTokenStore(t *testing.T) {
+	store := NewMultiTokenStore(MultiTokenOptions{Size: 3})
+	start := time.Now()
+	store.now = func() time.Time { return start }
+	for i := range 4 {
+		if err := store.Store("s", fmt.Sprintf("t%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The oldest token was dropped, the others stay valid and reusable.
+	if err := store.Validate("s", "t0"); !errors.Is(err, ErrTokenMismatch) {
+		t.Errorf("dropped token: %v", err)
+	}
+	for _, token := range []string{"t1", "t3", "t2", "t3"} {
+		if err := store.Validate("s", token); err != nil {
+			t.Errorf("%s: %v", token, err)
+		}
+	}
+	if err := store.Validate("other", "t1"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("unknown session: %v", err)
+	}
+	if err := store.Validate("s", ""); !errors.Is(err, ErrKeyOrTokenEmpty) {
+		t.Errorf("empty token: %v", err)
+	}
+
+	// Each token expires on its own.
+	store.now = func() time.Time { return start.Add(DefaultExpirationTime / 2) }
+	_ = store.Store("s", "late")
+	store.now = func() time.Time { return start.Add(DefaultExpirationTime + time.Second) }
+	if err := store.Validate("s", "t2"); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("old token: %v", err)
+	}
+	if err := store.Validate("s", "late"); err != nil {
+		t.Errorf("recent token: %v", err)
+	}
+
+	// Sessions without live tokens are swept on the next Store.
+	store.now = func() time.Time { return start.Add(2 * DefaultExpirationTime) }
+	_ = store.Store("new", "x")
+	if _, ok := store.sessions["s"]; ok {
+		t.Error("expired session was not swept")
+	}
+}
+
+func TestMultiTokenStore_OneTime(t *testing.T) {
+	store := NewMultiTokenStore(MultiTokenOptions{OneTime: true})
+	_ = store.Store("s", "a")
+	_ = store.Store("s", "b")
+
+	// Check leaves the token in place.
+	if err := store.Check("s", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Validate("s", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Check("s", "a"); !errors.Is(err, ErrTokenMismatch) {
+		t.Errorf("check after use: %v", err)
+	}
+	if err := store.Validate("s", "a"); !errors.Is(err, ErrTokenMismatch) {
+		t.Errorf("second use: %v", err)
+	}
+	if err := store.Validate("s", "b"); err != nil {
+		t.Errorf("other token: %v", err)
+	}
+}
@@ -20,9 +20,15 @@ var (
 	ErrTokenNotFound           = errors.New("csrf token not found")
 	ErrTokenExpired            = errors.New("csrf token expired")
 	ErrKeyOrTokenEmpty         = errors.New("key or token must not be empty")
+	ErrSecretTooShort          = errors.New("csrf secret must be at least 32 bytes")
+	ErrOriginMismatch          = errors.New("csrf origin does not match")
+	ErrRefererMissing          = errors.New("csrf referer missing")
+	ErrRefererMismatch         = errors.New("csrf referer does not match")
+	ErrCrossSiteRequest        = errors.New("csrf cross-site request")
 	DefaultSessionID           = "session_id"
 	DefaultExpirationTime      = 10 * time.Minute
 	DefaultCleanupIntervalTime = 10 * time.Minute
+	DefaultTokensPerSession    = 8
 )
 
 // Store defines an interface for storing and retrieving CSRF tokens.
@@ -35,6 +41,22 @@ type Store interface {
 	Validate(key, token string) error
 }
 
+// TokenGenerator is implemented by stores that create their own tokens, such as
+// HMACStore. The middleware calls GenerateToken instead of GenerateCSRFToken and Store.
+type TokenGenerator interface {
+	// GenerateToken returns a new token for the key
+	GenerateToken(key string) (string, error)
+}
+
+// TokenChecker is implemented by stores that can check a token without using it up,
+// such as MultiTokenStore. The middleware hands a token that is still valid back to
+// the request that sent it rather than storing a new one.
+type TokenChecker interface {
+	// Check reports whether token is valid for the key, like Validate, but leaves a
+	// one-time token in place
+	Check(key, token string) error
+}
+
 // GenerateCSRFToken creates a secure random token for CSRF protection
 func GenerateCSRFToken() (string, error) {
 	bytes := make([]byte, 32)
@@ -0,0 +1,83 @@
+package form
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCSRFMiddleware_Cookie(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	sessionCookie := func(options CSRFOptions, r *http.Request) *http.Cookie {
+		t.Helper()
+		w := httptest.NewRecorder()
+		NewForm().CSRFMiddlewareWithOptions(options)(okHandler).ServeHTTP(w, r)
+		cookies := w.Result().Cookies()
+		if len(cookies) != 1 {
+			t.Fatalf("expected one cookie, got %d", len(cookies))
+		}
+		return cookies[0]
+	}
+
+	c := sessionCookie(CSRFOptions{Cookie: CSRFCookie{
+		Name:        "__Host-csrf",
+		Domain:      "example.com",
+		Path:        "/app",
+		SameSite:    http.SameSiteStrictMode,
+		Partitioned: true,
+	}}, httptest.NewRequest(http.MethodGet, "/app", nil))
+	if c.Name != "__Host-csrf" || c.Domain != "example.com" || c.Path != "/app" || c.SameSite != http.SameSiteStrictMode || !c.Partitioned || !c.Secure || !c.HttpOnly {
+		t.Errorf("unexpected cookie %+v", c)
+	}
+
+	// Behind a TLS-terminating proxy, the forwarded protocol is only trusted when enabled.
+	proxied := httptest.NewRequest(http.MethodGet, "/", nil)
+	proxied.Header.Set("X-Forwarded-Proto", "https")
+	if c := sessionCookie(CSRFOptions{}, proxied); c.Secure || c.SameSite != http.SameSiteLaxMode || c.Path != "/" {
+		t.Errorf("unexpected default cookie %+v", c)
+	}
+	if c := sessionCookie(CSRFOptions{TrustForwardedProto: true}, proxied); !c.Secure {
+		t.Error("expected a Secure cookie with a trusted X-Forwarded-Proto")
+	}
+	if c := sessionCookie(CSRFOptions{Cookie: CSRFCookie{SameSite: http.SameSiteNoneMode}}, httptest.NewRequest(http.MethodGet, "/", nil)); !c.Secure {
+		t.Error("SameSite=None requires Secure")
+	}
+}
+
+func TestCSRFMiddleware_ExemptPathsAndMethods(t *testing.T) {
+	options := CSRFOptions{
+		ExemptPaths:      []string{"/hooks/", "/api/*/callback"},
+		ProtectedMethods: []string{http.MethodPost, "purge"},
+	}
+	handler := NewForm().CSRFMiddlewareWithOptions(options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodPost, "/hooks/stripe/events", http.StatusOK},
+		{http.MethodPost, "/api/github/callback", http.StatusOK},
+		{http.MethodPost, "/api/github/other", http.StatusBadRequest},
+		{http.MethodPost, "/hooks", http.StatusBadRequest},
+		{http.MethodPost, "/hooks/../admin/delete", http.StatusBadRequest},
+		{http.MethodPost, "/hooks//stripe/./events", http.StatusOK},
+		{http.MethodPost, "/api/github/./callback", http.StatusOK},
+		{"PURGE", "/cache", http.StatusBadRequest},
+		{http.MethodDelete, "/items/1", http.StatusOK},
+		{http.MethodGet, "/", http.StatusOK},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		if w.Code != tc.want {
+			t.Errorf("%s %s: status %d, want %d", tc.method, tc.path, w.Code, tc.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an invalid exempt path pattern")
+		}
+	}()
+	NewForm().CSRFMiddlewareWithOptions(CSRFOptions{ExemptPaths: []string{"/a/["}})
+}
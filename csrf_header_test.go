@@ -0,0 +1,152 @@
+package form
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/csrf"
+)
+
+func TestCSRFMiddleware_TokenSources(t *testing.T) {
+	f := NewForm()
+	var handlerErr error
+	options := CSRFOptions{
+		HeaderNames: []string{"X-XSRF-Token", DefaultCSRFHeader},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			handlerErr = err
+			w.WriteHeader(http.StatusForbidden)
+		},
+	}
+	handler := f.CSRFMiddlewareWithOptions(options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := GetCSRFToken(r)
+		w.Header().Set("X-Test-Token", token)
+	}))
+
+	session, freshToken := csrfTestSession(t, handler)
+
+	tests := []struct {
+		name    string
+		request func(token string) *http.Request
+		wantErr error
+	}{
+		{
+			name: "default header with JSON body",
+			request: func(token string) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"x"}`))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set(DefaultCSRFHeader, token)
+				return r
+			},
+		},
+		{
+			name: "configured header wins over the body",
+			request: func(token string) *http.Request {
+				r := httptest.NewRequest(http.MethodDelete, "/", strings.NewReader(url.Values{DefaultCSRFField: {"stale"}}.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				r.Header.Set("X-XSRF-Token", token)
+				return r
+			},
+		},
+		{
+			name: "multipart field",
+			request: func(token string) *http.Request {
+				var body bytes.Buffer
+				mw := multipart.NewWriter(&body)
+				_ = mw.WriteField("name", "x")
+				_ = mw.WriteField(DefaultCSRFField, token)
+				_ = mw.Close()
+				r := httptest.NewRequest(http.MethodPost, "/", &body)
+				r.Header.Set("Content-Type", mw.FormDataContentType())
+				return r
+			},
+		},
+		{
+			name: "query string is ignored",
+			request: func(token string) *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/?"+url.Values{DefaultCSRFField: {token}}.Encode(), nil)
+			},
+			wantErr: csrf.ErrTokenNotFound,
+		},
+		{
+			name: "wrong header token",
+			request: func(token string) *http.Request {
+				r := httptest.NewRequest(http.MethodPatch, "/", nil)
+				r.Header.Set(DefaultCSRFHeader, "wrong")
+				return r
+			},
+			wantErr: csrf.ErrTokenMismatch,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handlerErr = nil
+			r := tc.request(freshToken())
+			r.AddCookie(session)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if tc.wantErr != nil {
+				if !errors.Is(handlerErr, tc.wantErr) {
+					t.Errorf("error = %v, want %v", handlerErr, tc.wantErr)
+				}
+				return
+			}
+			if handlerErr != nil || w.Code != http.StatusOK || w.Header().Get("X-Test-Token") == "" {
+				t.Errorf("status %d, error %v, want the handler to run with a new token", w.Code, handlerErr)
+			}
+		})
+	}
+}
+
+func TestCSRFMetaHTML(t *testing.T) {
+	want := `<meta name="csrf-token" content="a+b/&#34;c=">`
+	ctx := context.WithValue(context.Background(), csrf.CSRFTokenContextKey, "a+b/\"c=")
+	r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+	for name, v := range map[string]any{
+		"token":   "a+b/\"c=",
+		"request": r,
+		"context": ctx,
+		"model":   WithInfo(struct{}{}, Info{CsrfValue: "a+b/\"c="}),
+	} {
+		if got := CSRFMetaHTML(v); string(got) != want {
+			t.Errorf("%s: got %s, want %s", name, got, want)
+		}
+	}
+	if got := CSRFMetaHTML(httptest.NewRequest(http.MethodGet, "/", nil)); got != "" {
+		t.Errorf("expected nothing without a token, got %s", got)
+	}
+	if _, ok := NewForm().FuncMap()["form_csrf_meta"]; !ok {
+		t.Error("form_csrf_meta missing from FuncMap")
+	}
+}
+
+// csrfTestSession starts a session on handler, which must set the X-Test-Token
+// header to the request's token. freshToken fetches a new token for the session.
+func csrfTestSession(t *testing.T, handler http.Handler) (session *http.Cookie, freshToken func() string) {
+	t.Helper()
+	wGet := httptest.NewRecorder()
+	handler.ServeHTTP(wGet, httptest.NewRequest(http.MethodGet, "/", nil))
+	for _, cookie := range wGet.Result().Cookies() {
+		if cookie.Name == csrf.DefaultSessionID {
+			session = cookie
+		}
+	}
+	if session == nil {
+		t.Fatal("no session cookie")
+	}
+	return session, func() string {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(session)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Header().Get("X-Test-Token")
+	}
+}
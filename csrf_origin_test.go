@@ -0,0 +1,95 @@
+package form
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/donseba/go-form/v2/csrf"
+)
+
+func TestCSRFMiddleware_OriginChecks(t *testing.T) {
+	f := NewForm()
+	var handlerErr error
+	options := DefaultCSRFOptions()
+	options.CheckOrigin = true
+	options.CheckFetchSite = true
+	options.StrictReferer = true
+	options.TrustedOrigins = []string{"https://admin.example.com/"}
+	options.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		handlerErr = err
+		w.WriteHeader(http.StatusForbidden)
+	}
+	handler := f.CSRFMiddlewareWithOptions(options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := GetCSRFToken(r)
+		w.Header().Set("X-Test-Token", token)
+	}))
+	session, freshToken := csrfTestSession(t, handler)
+
+	tests := []struct {
+		name    string
+		https   bool
+		headers map[string]string
+		wantErr error
+	}{
+		{name: "no headers over http"},
+		{name: "same origin", headers: map[string]string{"Origin": "http://example.com", "Sec-Fetch-Site": "same-origin"}},
+		{name: "same origin behind a TLS proxy", headers: map[string]string{"Origin": "https://example.com"}},
+		{name: "trusted origin", headers: map[string]string{"Origin": "https://Admin.example.com"}},
+		{name: "trusted cross-site origin", headers: map[string]string{"Origin": "https://admin.example.com", "Sec-Fetch-Site": "cross-site"}},
+		{name: "same site", headers: map[string]string{"Sec-Fetch-Site": "same-site"}},
+		{name: "other origin", headers: map[string]string{"Origin": "https://evil.test"}, wantErr: csrf.ErrOriginMismatch},
+		{name: "null origin", headers: map[string]string{"Origin": "null"}, wantErr: csrf.ErrOriginMismatch},
+		{name: "cross site", headers: map[string]string{"Sec-Fetch-Site": "cross-site"}, wantErr: csrf.ErrCrossSiteRequest},
+		{name: "https same referer", https: true, headers: map[string]string{"Referer": "https://example.com/form"}},
+		{name: "https trusted referer", https: true, headers: map[string]string{"Referer": "https://admin.example.com/x"}},
+		{name: "https origin instead of referer", https: true, headers: map[string]string{"Origin": "https://example.com"}},
+		{name: "https no referer", https: true, wantErr: csrf.ErrRefererMissing},
+		{name: "https other referer", https: true, headers: map[string]string{"Referer": "https://evil.test/"}, wantErr: csrf.ErrRefererMismatch},
+		{name: "https plain http referer", https: true, headers: map[string]string{"Referer": "http://example.com/form"}, wantErr: csrf.ErrRefererMismatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handlerErr = nil
+			r := httptest.NewRequest(http.MethodPost, "http://example.com/", nil)
+			if tc.https {
+				r.TLS = &tls.ConnectionState{}
+			}
+			r.Header.Set(DefaultCSRFHeader, freshToken())
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			r.AddCookie(session)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if !errors.Is(handlerErr, tc.wantErr) || (tc.wantErr == nil && handlerErr != nil) {
+				t.Errorf("error = %v, want %v", handlerErr, tc.wantErr)
+			}
+			if tc.wantErr == nil && w.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+		})
+	}
+
+	// The checks are opt-in: the default and the zero options only check the token.
+	for name, options := range map[string]CSRFOptions{"default": DefaultCSRFOptions(), "zero": {}} {
+		plain := NewForm().CSRFMiddlewareWithOptions(options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, _ := GetCSRFToken(r)
+			w.Header().Set("X-Test-Token", token)
+		}))
+		session, freshToken := csrfTestSession(t, plain)
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("Origin", "https://evil.test")
+		r.Header.Set("Sec-Fetch-Site", "cross-site")
+		r.Header.Set(DefaultCSRFHeader, freshToken())
+		r.AddCookie(session)
+		w := httptest.NewRecorder()
+		plain.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s options: checks should be off, status %d", name, w.Code)
+		}
+	}
+}
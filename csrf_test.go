@@ -613,10 +613,11 @@ func TestCSRFFailureCases(t *testing.T) {
 	}
 }
 
-// TestCSRFTokenReuseAttempt tests that a token cannot be reused after it's been consumed
+// TestCSRFTokenReuseAttempt tests that a one-time token cannot be reused after it's
+// been consumed
 func TestCSRFTokenReuseAttempt(t *testing.T) {
-	// Create form renderer
-	f := NewForm()
+	// Create form renderer with single-use tokens
+	f := New(WithCSRFStore(csrf.NewMultiTokenStore(csrf.MultiTokenOptions{OneTime: true})))
 
 	// Create a simple handler
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -681,3 +682,127 @@ func TestCSRFTokenReuseAttempt(t *testing.T) {
 		t.Errorf("Second POST with same token status = %v, want %v", wPost2.Code, http.StatusForbidden)
 	}
 }
+
+func TestCSRFMiddleware_HMACStoreAcrossServers(t *testing.T) {
+	secret := []byte(strings.Repeat("s", csrf.MinSecretLength))
+	newServer := func() http.Handler {
+		store, err := csrf.NewHMACStore(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := New(WithCSRFStore(store))
+		return f.CSRFMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, _ := GetCSRFToken(r)
+			w.Header().Set("X-Test-Token", token)
+		}))
+	}
+	a, b := newServer(), newServer()
+
+	wGet := httptest.NewRecorder()
+	a.ServeHTTP(wGet, httptest.NewRequest(http.MethodGet, "/", nil))
+	token := wGet.Header().Get("X-Test-Token")
+	cookies := wGet.Result().Cookies()
+	if token == "" || len(cookies) == 0 {
+		t.Fatal("expected a token and a session cookie")
+	}
+
+	// The POST lands on the other server, which keeps no state.
+	rPost := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{DefaultCSRFField: {token}}.Encode()))
+	rPost.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, c := range cookies {
+		rPost.AddCookie(c)
+	}
+	wPost := httptest.NewRecorder()
+	b.ServeHTTP(wPost, rPost)
+	if wPost.Code != http.StatusOK {
+		t.Fatalf("POST on the other server: status %d, body %q", wPost.Code, wPost.Body.String())
+	}
+	if next := wPost.Header().Get("X-Test-Token"); next == "" || next == token {
+		t.Errorf("expected a fresh token, got %q", next)
+	}
+}
+
+func TestCSRFMiddleware_MultipleTabs(t *testing.T) {
+	f := NewForm()
+	handler := f.CSRFMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := GetCSRFToken(r)
+		w.Header().Set("X-Test-Token", token)
+	}))
+	session, freshToken := csrfTestSession(t, handler)
+
+	post := func(token string) int {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{DefaultCSRFField: {token}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.AddCookie(session)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// Two tabs load the form; both can submit, the older one last. Tokens are not
+	// single-use by default.
+	first, second := freshToken(), freshToken()
+	if code := post(second); code != http.StatusOK {
+		t.Errorf("newer tab: status %d", code)
+	}
+	if code := post(first); code != http.StatusOK {
+		t.Errorf("older tab: status %d", code)
+	}
+	if code := post(first); code != http.StatusOK {
+		t.Errorf("resubmit: status %d", code)
+	}
+}
+
+func TestCSRFMiddleware_ReusableHeaderToken(t *testing.T) {
+	f := NewForm()
+	handler := f.CSRFMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := GetCSRFToken(r)
+		w.Header().Set("X-Test-Token", token)
+	}))
+	session, freshToken := csrfTestSession(t, handler)
+
+	// A page sends the token from its meta tag with every request.
+	token := freshToken()
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set(DefaultCSRFHeader, token)
+		r.AddCookie(session)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Errorf("request %d with the same header token: status %d", i+1, w.Code)
+		}
+	}
+}
+
+func TestCSRFMiddleware_BackgroundGETsKeepTabTokens(t *testing.T) {
+	f := NewForm()
+	handler := f.CSRFMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := GetCSRFToken(r)
+		w.Header().Set("X-Test-Token", token)
+	}))
+	session, freshToken := csrfTestSession(t, handler)
+	tab, poller := freshToken(), freshToken()
+
+	// More background GETs than the store keeps tokens; they send their token along
+	// and get it back instead of a new one.
+	for i := 0; i < 2*csrf.DefaultTokensPerSession; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(DefaultCSRFHeader, poller)
+		r.AddCookie(session)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if got := w.Header().Get("X-Test-Token"); got != poller {
+			t.Fatalf("background GET %d: got a new token", i+1)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{DefaultCSRFField: {tab}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(session)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("tab token was pushed out: status %d", w.Code)
+	}
+}
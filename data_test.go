@@ -118,6 +118,9 @@ func TestDataInRenderedHTML(t *testing.T) {
 		{"Plain", "plain"},
 		{"Tailwind", "tailwind"},
 		{"TailwindV4", "tailwindv4"},
+		{"Bulma", "bulma"},
+		{"Pico", "pico"},
+		{"Foundation", "foundation"},
 	}
 
 	for _, ts := range themeSets {
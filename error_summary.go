@@ -0,0 +1,79 @@
+package form
+
+import (
+	"html/template"
+
+	"github.com/donseba/go-form/v2/templates"
+	"github.com/donseba/go-form/v2/types"
+)
+
+// errorSummary lists every error in errs for the summary at the top of a form. Form
+// errors and errors for fields that are not rendered or hidden come first, in the
+// order of errs, then the errors of the visible fields in field order, each linking to
+// its field.
+func errorSummary(fields []types.FormField, errs FieldErrors, opts RenderOptions) []types.ErrorSummaryItem {
+	if len(errs) == 0 {
+		return nil
+	}
+	fieldErrors := opts.namespaceErrors(scanError(errs))
+	rendered := make(map[string]bool)
+	var linked []types.ErrorSummaryItem
+	walkFields(fields, func(field types.FormField) {
+		if field.InputType == types.InputFieldTypeHidden {
+			return
+		}
+		rendered[field.Name] = true
+		label := field.Label
+		if field.Type == types.FieldTypeGroup || field.Type == types.FieldTypeRepeater {
+			label = field.Legend
+		}
+		for _, msg := range fieldErrors[field.Name] {
+			linked = append(linked, types.ErrorSummaryItem{Id: errorAnchor(field), Label: label, Message: msg})
+		}
+	})
+
+	var items []types.ErrorSummaryItem
+	for _, err := range errs {
+		field, msg := err.FieldError()
+		if field == "" || !rendered[opts.qualify(field)] {
+			items = append(items, types.ErrorSummaryItem{Message: msg})
+		}
+	}
+	return append(items, linked...)
+}
+
+// walkFields calls fn for every field below the form, groups before their fields.
+func walkFields(fields []types.FormField, fn func(types.FormField)) {
+	for _, field := range fields {
+		if field.Type == types.FieldTypeForm {
+			continue
+		}
+		fn(field)
+		walkFields(field.Fields, fn)
+	}
+}
+
+// errorAnchor returns the id of the element the summary links to for field: the
+// control itself, the first option of an option list or the legend of a group.
+func errorAnchor(field types.FormField) string {
+	switch field.Type {
+	case types.FieldTypeGroup:
+		return field.Id + "_legend"
+	case types.FieldTypeMultiCheckbox:
+		return field.Id + "_0"
+	case types.FieldTypeRadios:
+		if tmpl, _ := controlTemplate(field); tmpl == "radio-group" && len(field.Values) > 0 {
+			return field.Id + "_0"
+		}
+	}
+	return field.Id
+}
+
+// errorSummaryHTML renders the summary with the theme's error-summary template. It is
+// empty without errors and for themes that have no such template.
+func (f *Form) errorSummaryHTML(theme *templates.Theme, loc types.Localizer, summary []types.ErrorSummaryItem) (template.HTML, error) {
+	if len(summary) == 0 || !theme.HasTemplate("error-summary") {
+		return "", nil
+	}
+	return f.themeHTML(theme, "error-summary", themeData{Loc: loc, Summary: summary})
+}
@@ -0,0 +1,85 @@
+package form
+
+import (
+	"strings"
+	"testing"
+)
+
+type errorSummaryForm struct {
+	Info
+	Name    string               `form:"input,text" label:"Name"`
+	Token   string               `form:"input,hidden"`
+	Colors  []string             `form:"multicheckbox" values:"red:Red;blue:Blue" label:"Colors"`
+	Address renderOptionsAddress `legend:"Address"`
+}
+
+func TestFormRender_ErrorSummary(t *testing.T) {
+	f := NewForm()
+	model := errorSummaryForm{Info: Info{Target: "/"}}
+	errs := FieldErrors{
+		FieldValidationError{Field: "Address.City", Err: "city is required"},
+		FormError{Err: "the form has expired"},
+		FieldValidationError{Field: "Name", Err: "name is required"},
+		FieldValidationError{Field: "Nickname", Err: "nickname is taken"},
+		FieldValidationError{Field: "Token", Err: "token is invalid"},
+		FieldValidationError{Field: "Colors", Err: "pick a color"},
+	}
+
+	html, err := f.formRender(model, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(html)
+	start := strings.Index(out, `role="alert" tabindex="-1"`)
+	if start < 0 || start > strings.Index(out, `name="Name"`) {
+		t.Fatalf("expected the error summary before the fields: %s", out)
+	}
+	summary := out[start : start+strings.Index(out[start:], "</ul>")]
+
+	want := []string{
+		"the form has expired",
+		"nickname is taken",
+		"token is invalid",
+		`<a href="#Name">Name: name is required</a>`,
+		`<a href="#Colors_0">Colors: pick a color</a>`,
+		`<a href="#Address.City">City: city is required</a>`,
+	}
+	last := -1
+	for _, w := range want {
+		i := strings.Index(summary, w)
+		if i < 0 {
+			t.Fatalf("missing %q in summary %s", w, summary)
+		}
+		if i < last {
+			t.Errorf("%q out of order in summary %s", w, summary)
+		}
+		last = i
+	}
+	if strings.Contains(summary, `href="#Token"`) {
+		t.Errorf("hidden fields must not be linked: %s", summary)
+	}
+	if !strings.Contains(out[strings.Index(out, `name="Name"`):], "name is required") {
+		t.Errorf("field errors should still show next to the field: %s", out)
+	}
+
+	html, err = f.formRender(model, errs, "namespace", "billing", "idPrefix", "b-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := string(html); !strings.Contains(out, `href="#b-billing.Name"`) || !strings.Contains(out, "the form has expired") {
+		t.Errorf("summary should follow the namespace and id prefix: %s", out)
+	}
+
+	html, err = f.formRender(model, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(html), "There is a problem") {
+		t.Errorf("unexpected summary without errors: %s", html)
+	}
+
+	html, err = f.formErrors(errs, "", "namespace", "billing")
+	if err != nil || !strings.Contains(string(html), "the form has expired") {
+		t.Errorf("form_errors for form errors: %q, %v", html, err)
+	}
+}
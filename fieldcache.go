@@ -0,0 +1,285 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+// typeInfo holds the parsed struct tags of one struct type. It is built once per
+// reflect.Type and shared by the transformer, the validator and MapForm, so tags are
+// parsed and regexes compiled only on first use.
+type typeInfo struct {
+	fields  []fieldInfo
+	hasInfo bool // the first field is an embedded Info
+}
+
+// fieldInfo is the parsed form of a single struct field's tags.
+type fieldInfo struct {
+	field reflect.StructField
+	name  string // form name: the name tag, or the Go field name
+
+	formTag     string
+	formType    types.FieldType
+	inputType   types.InputFieldType
+	label       string
+	placeholder string
+	description string
+	class       string
+	legend      string
+	step        string
+	min         string
+	max         string
+	rows        string
+	cols        string
+	maxLength   string
+	accept      string
+	maxFiles    string
+	widget      string
+	groupBefore string
+	groupAfter  string
+	required    bool
+	disabled    bool
+	translate   string
+	data        map[string]string
+	values      []types.FieldValue
+
+	isEnum         bool
+	isMapper       bool
+	isSortedMapper bool
+	isFile         bool
+	isTime         bool
+
+	pattern  *regexp.Regexp
+	validate []validatorCall
+
+	// tagErrs lists the tags of this field that cannot be used, such as a pattern that
+	// does not compile. They are computed once and returned by CheckValidateTags.
+	tagErrs []ValidateTagError
+}
+
+var (
+	typeInfoCache sync.Map // map[reflect.Type]*typeInfo
+
+	infoType    = reflect.TypeOf(Info{})
+	timePtrType = reflect.TypeOf(&time.Time{})
+)
+
+// cachedTypeInfo returns the parsed tags of struct type t.
+func cachedTypeInfo(t reflect.Type) *typeInfo {
+	if ti, ok := typeInfoCache.Load(t); ok {
+		return ti.(*typeInfo)
+	}
+	ti, _ := typeInfoCache.LoadOrStore(t, buildTypeInfo(t))
+	return ti.(*typeInfo)
+}
+
+func buildTypeInfo(t reflect.Type) *typeInfo {
+	ti := &typeInfo{
+		fields:  make([]fieldInfo, t.NumField()),
+		hasInfo: t.NumField() > 0 && t.Field(0).Anonymous && t.Field(0).Type == infoType,
+	}
+	for i := range ti.fields {
+		ti.fields[i] = buildFieldInfo(t, t.Field(i))
+	}
+	return ti
+}
+
+func buildFieldInfo(parent reflect.Type, sf reflect.StructField) fieldInfo {
+	tags := sf.Tag
+	fi := fieldInfo{
+		field:       sf,
+		name:        tags.Get(tagName),
+		formTag:     tags.Get(tagForm),
+		label:       tags.Get(tagLabel),
+		placeholder: tags.Get(tagPlaceholder),
+		description: tags.Get(tagDescription),
+		class:       tags.Get(tagClass),
+		legend:      tags.Get(tagLegend),
+		step:        tags.Get(tagStep),
+		min:         tags.Get(tagMin),
+		max:         tags.Get(tagMax),
+		rows:        tags.Get(tagRows),
+		cols:        tags.Get(tagCols),
+		maxLength:   tags.Get(tagMaxLength),
+		accept:      tags.Get(tagAccept),
+		maxFiles:    tags.Get(tagMaxFiles),
+		widget:      tags.Get(tagTemplate),
+		required:    tags.Get(tagRequired) == "true",
+		disabled:    tags.Get(tagDisabled) == "true",
+		translate:   tags.Get(tagTranslate),
+		data:        parseDataTag(tags.Get(tagData)),
+		values:      parseValuesTag(tags.Get(tagValues)),
+
+		isEnum:         sf.Type.Implements(enumType),
+		isMapper:       sf.Type.Implements(mapperType),
+		isSortedMapper: sf.Type.Implements(sortedMapperType),
+		isFile:         isFileType(sf.Type),
+		isTime:         sf.Type == timeType || sf.Type == timePtrType,
+	}
+	if fi.name == "" {
+		fi.name = sf.Name
+	}
+
+	if before, after, found := strings.Cut(tags.Get(tagGroup), ","); found {
+		fi.groupBefore = strings.TrimSpace(before)
+		fi.groupAfter = strings.TrimSpace(after)
+	} else {
+		fi.groupBefore = before
+	}
+
+	if strings.Contains(fi.formTag, ",") {
+		parts := strings.Split(fi.formTag, ",")
+		fi.formType = types.FieldType(parts[0])
+		fi.inputType = types.InputFieldType(parts[1])
+	} else {
+		fi.formType = types.FieldType(fi.formTag)
+	}
+
+	tagErr := func(key, tag string, err error) {
+		fi.tagErrs = append(fi.tagErrs, ValidateTagError{Field: sf.Name, Key: key, Tag: tag, Err: err.Error()})
+	}
+
+	if pattern := tags.Get("pattern"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			tagErr("pattern", pattern, err)
+		}
+		fi.pattern = re
+	}
+
+	if tag := tags.Get("validate"); tag != "" {
+		calls, err := parseValidateTag(tag)
+		if err != nil {
+			tagErr("validate", tag, err)
+		}
+		fi.validate = calls
+	}
+
+	for _, key := range crossFieldTags {
+		tag := tags.Get(key)
+		if tag == "" {
+			continue
+		}
+		names, err := crossFieldNames(key, tag)
+		if err != nil {
+			tagErr(key, tag, err)
+			continue
+		}
+		for _, name := range names {
+			if _, ok := parent.FieldByName(name); !ok {
+				tagErr(key, tag, fmt.Errorf("unknown field %q", name))
+			}
+		}
+	}
+
+	if fi.isFile {
+		if tag := tags.Get(tagMaxFiles); tag != "" {
+			if _, err := strconv.Atoi(tag); err != nil {
+				tagErr(tagMaxFiles, tag, fmt.Errorf("not an integer"))
+			}
+		}
+		if tag := tags.Get("maxSize"); tag != "" {
+			if _, err := parseByteSize(tag); err != nil {
+				tagErr("maxSize", tag, err)
+			}
+		}
+	}
+
+	kind := sf.Type.Kind()
+	if kind == reflect.Ptr {
+		kind = sf.Type.Elem().Kind()
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		for _, key := range []string{tagMin, tagMax, tagStep} {
+			if tag := tags.Get(key); tag != "" && !(key == tagStep && tag == "any") {
+				if _, err := strconv.ParseFloat(tag, 64); err != nil {
+					tagErr(key, tag, fmt.Errorf("not a number"))
+				}
+			}
+		}
+	case reflect.String:
+		for _, key := range []string{tagMaxLength, "minLength"} {
+			if tag := tags.Get(key); tag != "" {
+				if _, err := strconv.Atoi(tag); err != nil {
+					tagErr(key, tag, fmt.Errorf("not an integer"))
+				}
+			}
+		}
+	}
+	return fi
+}
+
+// parseDataTag parses data attributes such as `data:"toggle=modal,config={"a":1,"b":2}"`.
+// Commas inside quotes or braces do not separate attributes.
+func parseDataTag(dataTag string) map[string]string {
+	if dataTag == "" {
+		return nil
+	}
+	data := make(map[string]string)
+	addAttr := func(attr string) {
+		if key, value, found := strings.Cut(attr, "="); found {
+			data[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	var start int
+	var inQuote bool
+	var inBrace int
+	for i := 0; i < len(dataTag); i++ {
+		switch dataTag[i] {
+		case '"':
+			// Toggle quote state if not escaped
+			if i == 0 || dataTag[i-1] != '\\' {
+				inQuote = !inQuote
+			}
+		case '{':
+			if !inQuote {
+				inBrace++
+			}
+		case '}':
+			if !inQuote {
+				inBrace--
+			}
+		case ',':
+			// Only split on commas that are not inside quotes or braces
+			if !inQuote && inBrace == 0 {
+				addAttr(dataTag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(dataTag) {
+		addAttr(dataTag[start:])
+	}
+	return data
+}
+
+// parseValuesTag parses `values:"a:Label A;b:Label B"`; entries without a colon use the
+// value as label.
+func parseValuesTag(tag string) []types.FieldValue {
+	if tag == "" {
+		return nil
+	}
+	var values []types.FieldValue
+	for _, v := range strings.Split(tag, ";") {
+		if value, name, found := strings.Cut(v, ":"); found {
+			values = append(values, types.FieldValue{
+				Value: strings.TrimSpace(value),
+				Name:  strings.TrimSpace(name),
+			})
+			continue
+		}
+		values = append(values, types.FieldValue{Value: v, Name: v})
+	}
+	return values
+}
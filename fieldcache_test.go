@@ -0,0 +1,108 @@
+package form
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type cachedForm struct {
+	Info
+	Name  string `form:"input,text" name:"full_name" label:"Name" data:"toggle=modal,config={\"a\":1,\"b\":2}"`
+	Code  string `form:"input,text" pattern:"^[A-Z]{3}$"`
+	Color string `values:"r:Red;g:Green"`
+}
+
+type badCachedForm struct {
+	Code  string `pattern:"([a-z"`
+	Count int    `min:"one"`
+}
+
+func TestCachedTypeInfoIsShared(t *testing.T) {
+	typ := reflect.TypeOf(cachedForm{})
+	ti := cachedTypeInfo(typ)
+	if cachedTypeInfo(typ) != ti {
+		t.Fatal("expected the same cached type info")
+	}
+	if !ti.hasInfo {
+		t.Error("expected embedded Info to be detected")
+	}
+
+	name := ti.fields[1]
+	if name.name != "full_name" || name.label != "Name" || name.formType != "input" || name.inputType != "text" {
+		t.Errorf("unexpected field info: %+v", name)
+	}
+	if name.data["config"] != `{"a":1,"b":2}` || name.data["toggle"] != "modal" {
+		t.Errorf("unexpected data attributes: %v", name.data)
+	}
+	if ti.fields[2].pattern == nil {
+		t.Error("expected compiled pattern")
+	}
+	if len(ti.fields[3].values) != 2 || ti.fields[3].values[1].Name != "Green" {
+		t.Errorf("unexpected values: %v", ti.fields[3].values)
+	}
+}
+
+func TestTransformerDoesNotShareCachedData(t *testing.T) {
+	tr, err := NewTransformer(cachedForm{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.Fields[1].Data["toggle"] = "changed"
+	tr.Fields[3].Values[0].Name = "changed"
+
+	tr, err = NewTransformer(cachedForm{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Fields[1].Data["toggle"] != "modal" || tr.Fields[3].Values[0].Name != "Red" {
+		t.Error("mutating transformer output changed the cache")
+	}
+}
+
+func TestInvalidTagsAreReported(t *testing.T) {
+	f := NewForm()
+
+	err := f.CheckValidateTags(badCachedForm{})
+	var tagErr ValidateTagError
+	if !errors.As(err, &tagErr) || tagErr.Key != "pattern" {
+		t.Fatalf("expected pattern tag error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Count") {
+		t.Errorf("expected the Count tag error, got %v", err)
+	}
+
+	// The user only sees errors about their input, on every validation.
+	for i := 0; i < 2; i++ {
+		if errs := f.ValidateForm(&badCachedForm{Code: "abc", Count: 5}); len(errs) != 0 {
+			t.Errorf("unexpected tag errors in ValidateForm: %v", errs)
+		}
+	}
+
+	if errs := f.ValidateForm(&cachedForm{Code: "ABC"}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := f.ValidateForm(&cachedForm{Code: "abc"}); len(errs) != 1 {
+		t.Errorf("expected pattern mismatch, got %v", errs)
+	}
+}
+
+func BenchmarkNewTransformer(b *testing.B) {
+	model := cachedForm{Name: "Jane", Code: "ABC"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewTransformer(model); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateForm(b *testing.B) {
+	f := NewForm()
+	model := cachedForm{Name: "Jane", Code: "ABC"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.ValidateForm(&model)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
 	"reflect"
 	"sync"
@@ -19,6 +20,20 @@ type (
 	TranslationFunc func(loc types.Localizer, key string, args ...any) string
 	ValidationFunc  func(fieldValue any, fieldStruct reflect.StructField) FieldErrors
 
+	// ValidationFuncCtx is a validator that needs more than the field's own value, such
+	// as a request context for database lookups, the Localizer or sibling fields.
+	ValidationFuncCtx func(ctx context.Context, field FieldContext) FieldErrors
+
+	// FieldContext describes the field passed to a ValidationFuncCtx.
+	FieldContext struct {
+		Value     any                 // The field's value
+		Field     reflect.StructField // The field's struct definition, including tags
+		Path      string              // Dotted path from the root model, e.g. "Address.Street"
+		Parent    any                 // The struct holding the field, a pointer when addressable
+		Localizer Localizer           // The Localizer passed to validation
+		Args      []string            // Arguments from the validate tag, e.g. ["1", "10"] for between(1,10)
+	}
+
 	FieldErrors []FieldError
 
 	FieldError interface {
@@ -52,13 +67,25 @@ type (
 
 	Form struct {
 		validators         map[string]ValidationFunc
+		ctxValidators      map[string]ValidationFuncCtx
 		translationEnabled bool
 		translationFunc    TranslationFunc
 		csrfStore          csrf.Store // CSRF token storage
 
-		// themeName selects which embedded gohtml theme to use ("bootstrap", "tailwind", "tailwindv4", "plain").
+		// checkedTags remembers the result of checking the tags of a model type,
+		// keyed by reflect.Type. Registering a validator clears it.
+		checkedTags sync.Map
+
+		// themeName selects the gohtml theme to use, e.g. "bootstrap" or the variant
+		// "bootstrap:dark".
 		themeName string
+		// themes holds the themes owned by this form. They take precedence over the
+		// global registry in the templates package.
+		themes map[string]*templates.Theme
 	}
+
+	// Option configures a Form created with New.
+	Option func(*Form)
 )
 
 // WithInfo associates form metadata with a model for rendering. The model's
@@ -103,11 +130,61 @@ func (f *Form) GetCSRFStore() csrf.Store {
 	return f.csrfStore
 }
 
+// New creates a form configured by opts. Without options it behaves like NewForm.
+func New(opts ...Option) *Form {
+	f := NewForm()
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// WithTheme adds theme to the form's own theme registry and selects it. The theme
+// shadows a global theme with the same name for this form only.
+func WithTheme(theme *templates.Theme) Option {
+	return func(f *Form) {
+		f.AddTheme(theme)
+		f.themeName = theme.Name
+	}
+}
+
+// WithThemes adds themes to the form's own theme registry without selecting one.
+func WithThemes(themes ...*templates.Theme) Option {
+	return func(f *Form) {
+		for _, theme := range themes {
+			f.AddTheme(theme)
+		}
+	}
+}
+
+// WithTranslation enables translation with fn, like NewTranslatedForm.
+func WithTranslation(fn TranslationFunc) Option {
+	return func(f *Form) {
+		f.enableTranslation(fn)
+	}
+}
+
+// WithCSRFStore sets the store used for CSRF tokens.
+func WithCSRFStore(store csrf.Store) Option {
+	return func(f *Form) {
+		f.csrfStore = store
+	}
+}
+
+// AddTheme adds theme to the form's own theme registry. Select it with SetTheme.
+func (f *Form) AddTheme(theme *templates.Theme) {
+	if f.themes == nil {
+		f.themes = make(map[string]*templates.Theme)
+	}
+	f.themes[theme.Name] = theme
+}
+
 // NewTranslatedForm creates a new form with translation support.
 func NewTranslatedForm(translationFunc TranslationFunc) *Form {
 	f := &Form{
-		validators: make(map[string]ValidationFunc),
-		csrfStore:  csrf.NewDefaultMemoryCSRFStore(),
+		validators:    make(map[string]ValidationFunc),
+		ctxValidators: make(map[string]ValidationFuncCtx),
+		csrfStore:     newDefaultCSRFStore(),
 		// Default theme. Users can override via SetTheme(...).
 		themeName: "bootstrap",
 	}
@@ -119,8 +196,9 @@ func NewTranslatedForm(translationFunc TranslationFunc) *Form {
 // NewForm creates a new form without translation support.
 func NewForm() *Form {
 	return &Form{
-		validators: make(map[string]ValidationFunc),
-		csrfStore:  csrf.NewDefaultMemoryCSRFStore(),
+		validators:    make(map[string]ValidationFunc),
+		ctxValidators: make(map[string]ValidationFuncCtx),
+		csrfStore:     newDefaultCSRFStore(),
 		// Default theme. Users can override via SetTheme(...).
 		themeName: "bootstrap",
 	}
@@ -136,6 +214,15 @@ func (f *Form) FuncMap() template.FuncMap {
 		"form_render":           f.formRender,
 		"form_render_localized": f.formRenderLocalized,
 		"form_has_fields":       HasFields,
+		"form_field":            f.formField,
+		"form_field_localized":  f.formFieldLocalized,
+		"form_group":            f.formGroup,
+		"form_group_localized":  f.formGroupLocalized,
+		"form_errors":           f.formErrors,
+		"form_errors_localized": f.formErrorsLocalized,
+		"form_submit":           f.formSubmit,
+		"form_submit_localized": f.formSubmitLocalized,
+		"form_csrf_meta":        CSRFMetaHTML,
 	}
 
 	return funcMap
@@ -191,87 +278,92 @@ func (f *Form) themePrint(loc types.Localizer, key string, args ...any) string {
 	return key
 }
 
-func (f *Form) formRenderFunc(loc types.Localizer, v any, errs FieldErrors, _ ...any) (template.HTML, error) {
-	theme, err := f.getTheme()
+func (f *Form) formRenderFunc(loc types.Localizer, v any, errs FieldErrors, kv ...any) (template.HTML, error) {
+	opts, err := parseRenderOptions(kv)
 	if err != nil {
 		return "", err
 	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := f.RenderWithOptions(buf, loc, v, errs, opts); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
 
-	tr, err := NewTransformer(v)
+// Render writes the form for model to w using the selected theme, with errs shown
+// next to their fields. Field markup is assembled in pooled buffers and the form
+// template streams into w, so w may hold partial output when an error is returned.
+func (f *Form) Render(w io.Writer, loc Localizer, model any, errs FieldErrors) error {
+	return f.RenderWithOptions(w, loc, model, errs, RenderOptions{})
+}
+
+// RenderWithOptions is Render with per-render options, see RenderOptions.
+func (f *Form) RenderWithOptions(w io.Writer, loc Localizer, model any, errs FieldErrors, opts RenderOptions) error {
+	theme, ok, err := f.renderTheme(w, opts)
+	if !ok {
+		return err
+	}
+	loc = f.printLoc(loc)
+
+	tr, err := NewTransformerWithNamespace(model, opts.Namespace)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if err := f.checkModelTags(model); err != nil {
+		return err
+	}
+	fields, err := opts.apply(tr.Fields)
+	if err != nil {
+		return err
 	}
-	fieldErrors := scanError(errs)
+	fieldErrors := opts.namespaceErrors(scanError(errs))
 
-	// Build formField and inner HTML.
-	var inner template.HTML
-	var formField *types.FormField
+	inner := getBuffer()
+	defer putBuffer(inner)
 
-	for i, field := range tr.Fields {
+	var formField *types.FormField
+	for i, field := range fields {
 		if field.Type == types.FieldTypeForm {
-			formField = &tr.Fields[i]
+			formField = &fields[i]
 			if formField.Attributes == nil {
 				formField.Attributes = make(map[string]string)
 			}
-			// Submit text is already set by the Transformer into FormField.Label, so don't overwrite it here.
-			continue
-		}
-
-		if field.Type == types.FieldTypeGroup {
-			funcs := template.FuncMap{
-				"fields": func() template.HTML {
-					return f.renderGroupFields(theme, loc, field.Fields, fieldErrors)
-				},
-				"errors": func() []string {
-					if es, ok := fieldErrors[field.Name]; ok {
-						return es
-					}
-					return nil
-				},
-			}
-
-			h, err := f.themeExec(theme, "group", map[string]any{"Field": field, "Loc": loc}, funcs)
-			if err != nil {
-				return "", err
+			for key, value := range opts.Attributes {
+				formField.Attributes[key] = value
 			}
-			inner += h
+			// Submit text is already set by the Transformer into FormField.Label, so don't overwrite it here.
 			continue
 		}
 
-		fh, err := f.themeFieldHTML(theme, loc, field, fieldErrors)
-		if err != nil {
-			return "", err
+		if err := f.themeElement(inner, theme, loc, field, fieldErrors); err != nil {
+			return err
 		}
-		inner += fh
 	}
 
 	if formField == nil {
-		return inner, nil
-	}
-
-	csrfHTML := csrfHiddenInputHTML(v)
-
-	funcs := template.FuncMap{
-		"fields": func() template.HTML { return csrfHTML + inner },
-	}
-
-	formData := struct {
-		Field types.FormField
-		Loc   types.Localizer
-	}{
-		Field: *formField,
-		Loc:   loc,
+		_, err := inner.WriteTo(w)
+		return err
 	}
 
-	h, err := f.themeExec(theme, "form", formData, funcs)
+	summary, err := f.errorSummaryHTML(theme, loc, errorSummary(fields, errs, opts))
 	if err != nil {
-		return "", err
+		return err
 	}
-	return h, nil
+
+	return f.themeExec(w, theme, "form", themeData{
+		Field:        *formField,
+		Loc:          loc,
+		Fields:       csrfHiddenInputHTML(model) + template.HTML(inner.String()),
+		ErrorSummary: summary,
+		HideSubmit:   opts.HideSubmit || opts.ReadOnly,
+		ReadOnly:     opts.ReadOnly,
+	})
 }
 
 func (f *Form) RegisterValidationMethod(name string, fn ValidationFunc) {
 	f.validators[name] = fn
+	f.checkedTags.Clear()
 }
 
 func (f *Form) GetValidationMethod(name string) (ValidationFunc, bool) {
@@ -279,6 +371,19 @@ func (f *Form) GetValidationMethod(name string) (ValidationFunc, bool) {
 	return fn, ok
 }
 
+// RegisterValidationMethodCtx registers a context-aware validator under name for use
+// in `validate` tags. It takes precedence over a ValidationFunc with the same name.
+func (f *Form) RegisterValidationMethodCtx(name string, fn ValidationFuncCtx) {
+	f.ctxValidators[name] = fn
+	f.checkedTags.Clear()
+}
+
+// Error returns a FieldError for this field. Like every validator error it is relative
+// to the parent struct; nested paths are prefixed by the validation walk.
+func (fc FieldContext) Error(msg string) FieldError {
+	return FieldValidationError{Field: fc.Field.Name, Err: msg}
+}
+
 func scanError(errs FieldErrors) map[string][]string {
 	ret := make(map[string][]string)
 	for _, err := range errs {
@@ -293,43 +398,52 @@ func (f *Form) SetTheme(name string) {
 	f.themeName = name
 }
 
-func (f *Form) themeFieldHTML(theme *templates.Theme, loc types.Localizer, field types.FormField, errorMap map[string][]string) (template.HTML, error) {
+func (f *Form) themeField(w io.Writer, theme *templates.Theme, loc types.Localizer, field types.FormField, errorMap map[string][]string) error {
 	// Render label
-	labelH, err := f.themeExec(theme, "label", map[string]any{"Field": field, "Loc": loc}, nil)
+	labelH, err := f.themeHTML(theme, "label", themeData{Field: field, Loc: loc})
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// Render control
-	var control template.HTML
+	// Render control, with the field's widget template when the theme has it.
+	tmplName, err := controlTemplate(field)
+	if field.Widget != "" && theme.HasTemplate(field.Widget) {
+		tmplName, err = field.Widget, nil
+	}
+	if err != nil {
+		return err
+	}
+	control, err := f.themeHTML(theme, tmplName, themeData{Type: field.InputType.String(), Field: field, Loc: loc})
+	if err != nil {
+		return err
+	}
+
+	control, err = f.themeWrapIfGrouped(theme, field, control)
+	if err != nil {
+		return err
+	}
+
+	return f.themeWrapField(w, theme, loc, field, labelH, control, errorMap)
+}
+
+// controlTemplate returns the name of the theme template that renders field's control.
+func controlTemplate(field types.FormField) (string, error) {
 	switch field.Type {
 	case types.FieldTypeInput:
-		control, err = f.themeExec(theme, "input", map[string]any{"Type": field.InputType.String(), "Field": field, "Loc": loc}, nil)
+		return "input", nil
 	case types.FieldTypeDropdown, types.FieldTypeDropdownMapped:
-		control, err = f.themeExec(theme, "select", map[string]any{"Field": field, "Loc": loc}, nil)
+		return "select", nil
 	case types.FieldTypeTextArea:
-		control, err = f.themeExec(theme, "textarea", map[string]any{"Field": field, "Loc": loc}, nil)
+		return "textarea", nil
 	case types.FieldTypeCheckbox:
-		control, err = f.themeExec(theme, "checkbox", map[string]any{"Field": field, "Loc": loc}, nil)
+		return "checkbox", nil
 	case types.FieldTypeRadios:
-		name := "radio"
 		if field.InputType == types.InputFieldTypeRadioStruct || field.InputType == types.InputFieldTypeRadioGroup {
-			name = "radio-group"
+			return "radio-group", nil
 		}
-		control, err = f.themeExec(theme, name, map[string]any{"Field": field, "Loc": loc}, nil)
+		return "radio", nil
 	case types.FieldTypeMultiCheckbox:
-		control, err = f.themeExec(theme, "multicheckbox", map[string]any{"Field": field, "Loc": loc}, nil)
-	default:
-		return "", fmt.Errorf("unsupported field type %q for theme renderer", field.Type)
+		return "multicheckbox", nil
 	}
-	if err != nil {
-		return "", err
-	}
-
-	control, err = f.themeWrapIfGrouped(theme, field, control)
-	if err != nil {
-		return "", err
-	}
-
-	return f.themeWrapField(theme, loc, field, labelH, control, errorMap)
+	return "", fmt.Errorf("unsupported field type %q for theme renderer", field.Type)
 }
@@ -3,7 +3,10 @@ package form
 import (
 	"html/template"
 	"io"
+	"strings"
 	"testing"
+
+	"github.com/donseba/go-form/v2/types"
 )
 
 func TestForm_Render_UsesGoHTMLTheme(t *testing.T) {
@@ -21,3 +24,106 @@ func TestForm_Render_UsesGoHTMLTheme(t *testing.T) {
 		t.Fatalf("execute: %v", err)
 	}
 }
+
+func TestForm_Render_SharesThemeTemplates(t *testing.T) {
+	type Simple struct {
+		Info
+		Name string `form:"input,text" label:"Name"`
+	}
+
+	f := NewTranslatedForm(func(loc types.Localizer, key string, args ...any) string {
+		return "t:" + key
+	})
+	html, err := f.formRender(Simple{Info: Info{Target: "/"}}, nil)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.Contains(string(html), "t:Name") {
+		t.Errorf("expected translated label, got %s", html)
+	}
+
+	// Rendering must not execute the theme's own template set, so it can still be cloned.
+	theme, err := f.getTheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := theme.Templates.Clone(); err != nil {
+		t.Errorf("theme templates can no longer be cloned: %v", err)
+	}
+}
+
+func TestForm_Render_WritesToWriter(t *testing.T) {
+	type Simple struct {
+		Info
+		Name string `form:"input,text" label:"Name"`
+	}
+	data := Simple{Info: Info{Target: "/save", Method: "post", CsrfValue: "tok"}, Name: "Jane"}
+	errs := FieldErrors{FieldValidationError{Field: "Name", Err: "too short"}}
+
+	f := NewForm()
+	var sb strings.Builder
+	if err := f.Render(&sb, nil, data, errs); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	html, err := f.formRender(data, errs)
+	if err != nil {
+		t.Fatalf("form_render: %v", err)
+	}
+	if sb.String() != string(html) {
+		t.Errorf("Render and form_render differ:\n%s\n---\n%s", sb.String(), html)
+	}
+	for _, want := range []string{`action="/save"`, `value="tok"`, `value="Jane"`, "too short"} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("expected %q in output: %s", want, sb.String())
+		}
+	}
+
+	if err := f.Render(io.Discard, nil, 42, nil); err == nil {
+		t.Error("expected error for non-struct model")
+	}
+}
+
+func TestForm_Render_ThemeStructure(t *testing.T) {
+	type Address struct {
+		City string `form:"input,text" label:"City"`
+	}
+	type Order struct {
+		Info
+		Amount  string  `form:"input,text" label:"Amount" group:"€"`
+		Country string  `form:"dropdown" label:"Country" values:"nl:Netherlands;be:Belgium"`
+		Terms   bool    `form:"checkbox" label:"Accept"`
+		Address Address `legend:"Address"`
+	}
+	model := Order{Info: Info{Target: "/"}}
+
+	cases := map[string][]string{
+		"bulma": {
+			`<div class="control">`,
+			`class="field has-addons"`,
+			`class="select is-fullwidth">`,
+			`class="checkbox" for="Terms"`,
+		},
+		"pico": {
+			`<div role="group"`,
+			`<article`,
+		},
+		"foundation": {
+			`class="input-group"`,
+			`class="input-group-label"`,
+			`class="card-section"`,
+		},
+	}
+	for theme, wants := range cases {
+		f := NewForm()
+		f.SetTheme(theme)
+		html, err := f.formRender(model, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", theme, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(html), want) {
+				t.Errorf("%s: rendered form is missing %q:\n%s", theme, want, html)
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package form
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/donseba/go-form/v2/templates"
+)
+
+func TestForm_Render_HotReloadParseError(t *testing.T) {
+	type Simple struct {
+		Info
+		Name string `form:"input,text" label:"Name"`
+	}
+	model := Simple{Info: Info{Target: "/"}}
+
+	theme, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := theme.EnableHotReload(dir, 0); err != nil {
+		t.Fatalf("EnableHotReload: %v", err)
+	}
+	f := New(WithTheme(theme))
+
+	if err := os.WriteFile(filepath.Join(dir, "input.gohtml"), []byte(`{{ if .Field.Name }}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	html, err := f.formRender(model, nil)
+	if err != nil {
+		t.Fatalf("parse errors should be rendered, got %v", err)
+	}
+	out := string(html)
+	if !strings.Contains(out, "go-form-template-error") || !strings.Contains(out, "input") {
+		t.Fatalf("expected rendered parse error, got %s", out)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "input.gohtml"), []byte(`<input class="reloaded" name="{{ .Field.Name }}">`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	html, err = f.formRender(model, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := string(html); !strings.Contains(out, `class="reloaded"`) || strings.Contains(out, "go-form-template-error") {
+		t.Fatalf("expected reloaded template, got %s", out)
+	}
+}
+
+// TestForm_Render_ConcurrentHotReload renders while the templates are reloaded. It
+// is meant for go test -race.
+func TestForm_Render_ConcurrentHotReload(t *testing.T) {
+	type Simple struct {
+		Info
+		Name string `form:"input,text" label:"Name"`
+	}
+	model := Simple{Info: Info{Target: "/"}}
+
+	theme, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := theme.EnableHotReload(dir, 0); err != nil {
+		t.Fatalf("EnableHotReload: %v", err)
+	}
+	f := New(WithTheme(theme))
+
+	// Rewrite the template until the renders are done.
+	stop := make(chan struct{})
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			// Write and rename, so a reload never reads a half-written file. The size
+			// alternates, so every write is seen as a change even when the file system
+			// keeps coarse modification times.
+			text := fmt.Sprintf(`<input class="v%d" name="{{ .Field.Name }}">`, i%2) + strings.Repeat(" ", i%2)
+			tmp := filepath.Join(dir, "input.tmp")
+			if err := os.WriteFile(tmp, []byte(text), 0o644); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := os.Rename(tmp, filepath.Join(dir, "input.gohtml")); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	// Look the theme up without rendering, as every render starts with.
+	looked := make(chan struct{})
+	go func() {
+		defer close(looked)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, err := f.getTheme(); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				html, err := f.formRender(model, nil)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if strings.Contains(string(html), "go-form-template-error") {
+					t.Errorf("unexpected template error: %s", html)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-written
+	<-looked
+}
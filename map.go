@@ -1,9 +1,12 @@
 package form
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,8 +20,95 @@ var (
 )
 
 // MapForm maps form values from an http.Request to a struct based on the `name` tag.
-// Only exported fields are set. Supports string, int, float64, and bool fields.
+// Only exported fields are set. Supports string, int, float64, and bool fields, and
+// uploaded files for *multipart.FileHeader, []*multipart.FileHeader and FileUpload fields.
+//
+// Values that cannot be converted to the field type are skipped. Use MapFormWithErrors
+// or Form.Bind to receive them as FieldErrors.
+//
+// The optional prefix is put in front of every field name. A form rendered with the
+// namespace "billing" (see RenderOptions.Namespace) binds with the prefix "billing.".
 func MapForm(r *http.Request, dst any, prefixes ...string) error {
+	prefix := ""
+	if len(prefixes) > 0 {
+		prefix = prefixes[0]
+	}
+	return mapForm(r, dst, prefix, nil)
+}
+
+// MapFormWithErrors behaves like MapForm but also returns a FieldError for every
+// submitted value that could not be bound, keyed by the rendered field name so the
+// errors can be passed straight to form_render. Messages are not translated; use
+// Form.Bind or Form.BindLocalized for translated messages.
+func MapFormWithErrors(r *http.Request, dst any, prefixes ...string) (FieldErrors, error) {
+	prefix := ""
+	if len(prefixes) > 0 {
+		prefix = prefixes[0]
+	}
+	var bindErrs []BindError
+	if err := mapForm(r, dst, prefix, &bindErrs); err != nil {
+		return nil, err
+	}
+	return bindFieldErrors(bindErrs, func(key string, args ...any) string {
+		return fmt.Sprintf(key, args...)
+	}), nil
+}
+
+// Bind maps the request onto dst like MapForm and returns binding failures as
+// translated FieldErrors. A tag of dst that cannot be used, such as a `validate` tag
+// naming an unregistered validator, is returned as the error; see CheckValidateTags.
+func (f *Form) Bind(r *http.Request, dst any, prefixes ...string) (FieldErrors, error) {
+	return f.BindLocalized(r, dst, &DefaultLocalizer{}, prefixes...)
+}
+
+// BindLocalized is Bind with an explicit Localizer for the error messages.
+func (f *Form) BindLocalized(r *http.Request, dst any, loc Localizer, prefixes ...string) (FieldErrors, error) {
+	if err := f.checkModelTags(dst); err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if len(prefixes) > 0 {
+		prefix = prefixes[0]
+	}
+	var bindErrs []BindError
+	if err := mapForm(r, dst, prefix, &bindErrs); err != nil {
+		return nil, err
+	}
+	return bindFieldErrors(bindErrs, func(key string, args ...any) string {
+		return f.validationErrorTranslated(loc, key, args...)
+	}), nil
+}
+
+// BindError describes a submitted value that could not be converted to its field type.
+type BindError struct {
+	Field string // Rendered field name, e.g. "Address.Zip"
+	Key   string // Translation key, one of the TranslationKey* variables
+	Args  []any  // Arguments for Key
+	Err   string // Message, formatted or translated from Key and Args
+}
+
+// Error implements the error interface for BindError.
+func (e BindError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Err)
+}
+
+// FieldError returns the field and error message.
+func (e BindError) FieldError() (field, err string) {
+	return e.Field, e.Err
+}
+
+func bindFieldErrors(bindErrs []BindError, format func(key string, args ...any) string) FieldErrors {
+	var errs FieldErrors
+	for _, be := range bindErrs {
+		be.Err = format(be.Key, be.Args...)
+		errs = append(errs, be)
+	}
+	return errs
+}
+
+// mapForm does the work for MapForm and the Bind variants. When bindErrs is nil,
+// conversion failures are ignored and SetFromKeys failures are returned as before.
+func mapForm(r *http.Request, dst any, prefix string, bindErrs *[]BindError) error {
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr || v.IsNil() {
 		return ErrMapFormNotPointer
@@ -27,18 +117,28 @@ func MapForm(r *http.Request, dst any, prefixes ...string) error {
 	if v.Kind() != reflect.Struct {
 		return ErrMapFormNotStruct
 	}
-	prefix := ""
-	if len(prefixes) > 0 {
-		prefix = prefixes[0]
+
+	fail := func(field, key string, args ...any) {
+		if bindErrs != nil {
+			*bindErrs = append(*bindErrs, BindError{Field: field, Key: key, Args: args})
+		}
 	}
-	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
+
+	ti := cachedTypeInfo(v.Type())
+	for i := range ti.fields {
+		fi := &ti.fields[i]
+		field := fi.field
 		fv := v.Field(i)
 		if !fv.CanSet() {
 			continue
 		}
 
+		// Uploaded files live in r.MultipartForm rather than in the form values.
+		if fi.isFile {
+			mapFileField(r, fv, prefix+fi.name)
+			continue
+		}
+
 		// Recursively map nested structs (skip time.Time and Info)
 		if fv.Kind() == reflect.Struct &&
 			!(field.Type.PkgPath() == "time" && field.Type.Name() == "Time") &&
@@ -50,34 +150,38 @@ func MapForm(r *http.Request, dst any, prefixes ...string) error {
 			} else if fv.Addr().Type().Implements(reflect.TypeOf((*interface{ SetFromKeys([]string) error })(nil)).Elem()) {
 				// do not recurse; let the SetFromKeys path handle this field later
 			} else {
-				name := field.Tag.Get("name")
-				if name == "" {
-					name = field.Name
+				// MapForm has always ignored errors from nested structs.
+				if err := mapForm(r, fv.Addr().Interface(), prefix+fi.name+".", bindErrs); err != nil && bindErrs != nil {
+					return err
 				}
-				_ = MapForm(r, fv.Addr().Interface(), prefix+name+".")
 				continue
 			}
 		}
-		formKey := field.Tag.Get("name")
-		if formKey == "" {
-			formKey = field.Name
+		key := prefix + fi.name
+
+		if r == nil {
+			continue
+		}
+
+		// Slices bind from repeated values (Tags=a&Tags=b) or indexed names
+		// (Tags.0, Items.0.Name, Items[0].Name).
+		if fv.Kind() == reflect.Slice && !implementsKeySetter(fv) &&
+			(sliceStructElem(fv.Type().Elem()) != nil || isScalarSliceElem(fv.Type().Elem().Kind())) {
+			mapSlice(r, fv, key, fail, bindErrs)
+			continue
 		}
 
 		// Handle boolean fields specially
 		if fv.Kind() == reflect.Bool {
 			// Check if value exists in form - useful for checkboxes
-			if r != nil && r.Form != nil {
-				formValue := r.FormValue(prefix + formKey)
+			if r.Form != nil {
+				formValue := r.FormValue(key)
 
 				// Check if form value exists
-				if _, exists := r.Form[prefix+formKey]; exists {
+				if _, exists := r.Form[key]; exists {
 					// For checkboxes, "on" means true
-					if formValue == "on" || formValue == "true" || formValue == "1" {
-						fv.SetBool(true)
-					} else if formValue == "off" || formValue == "false" || formValue == "0" || formValue == "" {
-						fv.SetBool(false)
-					} else if bv, err := strconv.ParseBool(formValue); err == nil {
-						fv.SetBool(bv)
+					if failKey := setScalarValue(fv, formValue); failKey != "" {
+						fail(key, failKey, formValue)
 					}
 				} else {
 					// If checkbox isn't in the form at all, it's unchecked
@@ -87,8 +191,33 @@ func MapForm(r *http.Request, dst any, prefixes ...string) error {
 			continue
 		}
 
+		// A multi-select binds every submitted value. The first one may be the empty
+		// hidden input of a multicheckbox, which is submitted even when nothing is
+		// checked and clears the selection.
+		if fv.CanAddr() {
+			if setter, ok := fv.Addr().Interface().(interface{ SetFromKeys([]string) error }); ok {
+				var formValues []string
+				if r.Method == http.MethodPost && r.PostForm != nil {
+					formValues = r.PostForm[key]
+				} else if r.Form != nil {
+					formValues = r.Form[key]
+				}
+
+				if len(formValues) > 0 {
+					formValues = slices.DeleteFunc(slices.Clone(formValues), func(v string) bool { return v == "" })
+					if err := setter.SetFromKeys(formValues); err != nil {
+						if bindErrs == nil {
+							return err
+						}
+						fail(key, TranslationKeyInvalidValue, strings.Join(formValues, ", "))
+					}
+				}
+				continue
+			}
+		}
+
 		// For non-boolean fields, proceed as before
-		formValue := r.FormValue(prefix + formKey)
+		formValue := r.FormValue(key)
 		if formValue == "" {
 			continue
 		}
@@ -98,23 +227,12 @@ func MapForm(r *http.Request, dst any, prefixes ...string) error {
 			addr := fv.Addr().Interface()
 			if setter, ok := addr.(interface{ SetFromKey(string) error }); ok {
 				if err := setter.SetFromKey(formValue); err != nil {
-					// Log and continue; mapping shouldn't abort the whole form
-					fmt.Printf("error setting key from form for field %s: %v\n", field.Name, err)
-				}
-				continue
-			}
-
-			if setter, ok := addr.(interface{ SetFromKeys([]string) error }); ok {
-				var formValues []string
-				if r.Method == http.MethodPost && r.PostForm != nil {
-					formValues = r.PostForm[prefix+formKey]
-				} else if r.Form != nil {
-					formValues = r.Form[prefix+formKey]
-				}
-
-				if len(formValues) > 0 {
-					if err := setter.SetFromKeys(formValues); err != nil {
-						return err
+					// Mapping shouldn't abort the whole form
+					var sserr SortedSelectError
+					if errors.As(err, &sserr) {
+						fail(key, sserr.Key, sserr.Args...)
+					} else {
+						fail(key, TranslationKeyInvalidValue, formValue)
 					}
 				}
 				continue
@@ -124,57 +242,237 @@ func MapForm(r *http.Request, dst any, prefixes ...string) error {
 		// If this is a primitive kind, use the shared helper. For arrays/structs/pointers
 		// we fall through to the special-case handling below (UUID, time.Time, etc.).
 		switch fv.Kind() {
-		case reflect.String:
-			fv.SetString(formValue)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if iv, err := strconv.ParseInt(formValue, 10, 64); err == nil {
-				fv.SetInt(iv)
-			}
-		case reflect.Float32, reflect.Float64:
-			if fv64, err := strconv.ParseFloat(formValue, 64); err == nil {
-				fv.SetFloat(fv64)
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if failKey := setScalarValue(fv, formValue); failKey != "" {
+				fail(key, failKey, formValue)
 			}
 		case reflect.Array:
 			// Handle UUID arrays ([16]byte typically)
 			if fv.Type().Elem().Kind() == reflect.Uint8 && fv.Len() == 16 {
-				// Try to parse UUID string (format: "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
-				if len(formValue) == 36 {
-					hexString := strings.ReplaceAll(formValue, "-", "")
-					if len(hexString) == 32 {
-						for i := 0; i < fv.Len() && i < len(hexString)/2; i++ {
-							// Convert each pair of hex chars to byte
-							if b, err := strconv.ParseUint(hexString[i*2:i*2+2], 16, 8); err == nil {
-								fv.Index(i).SetUint(b)
-							}
-						}
-					}
+				// Parse UUID string (format: "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
+				if !parseUUIDToFieldValue(fv, formValue) {
+					fail(key, TranslationKeyBindUUID, formValue)
 				}
-			} else {
-				fmt.Printf("unsupported array field type %s for field %s\n", fv.Type().Elem().Kind(), field.Name)
 			}
 		case reflect.Struct:
 			if field.Type.PkgPath() == "time" && field.Type.Name() == "Time" {
-				err := parseTimeToFieldValue(fv, field, formValue)
-				if err != nil {
-					fmt.Printf("error parsing time field %s: %s", field.Name, err)
-					continue
+				if err := parseTimeToFieldValue(fv, field, formValue); err != nil {
+					fail(key, TranslationKeyBindTime, formValue)
 				}
 			}
 		case reflect.Ptr:
 			if field.Type.Elem().PkgPath() == "time" && field.Type.Elem().Name() == "Time" {
-				err := parseTimeToFieldValue(fv, field, formValue)
-				if err != nil {
-					fmt.Printf("error parsing time field %s: %s", field.Name, err)
-					continue
+				if err := parseTimeToFieldValue(fv, field, formValue); err != nil {
+					fail(key, TranslationKeyBindTime, formValue)
 				}
 			}
-		default:
-			fmt.Printf("unsupported field type %s for field %s\n", fv.Kind(), field.Name)
 		}
 	}
 	return nil
 }
 
+// setScalarValue converts formValue into fv, which must be a string, bool or numeric
+// kind. It returns the translation key describing the failure, or "" on success.
+func setScalarValue(fv reflect.Value, formValue string) string {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(formValue)
+	case reflect.Bool:
+		switch formValue {
+		case "on", "true", "1":
+			fv.SetBool(true)
+		case "off", "false", "0", "":
+			fv.SetBool(false)
+		default:
+			bv, err := strconv.ParseBool(formValue)
+			if err != nil {
+				return TranslationKeyBindBool
+			}
+			fv.SetBool(bv)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iv, err := strconv.ParseInt(formValue, 10, fv.Type().Bits())
+		if err != nil {
+			return TranslationKeyBindNumber
+		}
+		fv.SetInt(iv)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uv, err := strconv.ParseUint(formValue, 10, fv.Type().Bits())
+		if err != nil {
+			return TranslationKeyBindNumber
+		}
+		fv.SetUint(uv)
+	case reflect.Float32, reflect.Float64:
+		fv64, err := strconv.ParseFloat(formValue, fv.Type().Bits())
+		if err != nil {
+			return TranslationKeyBindNumber
+		}
+		fv.SetFloat(fv64)
+	}
+	return ""
+}
+
+// implementsKeySetter reports whether fv binds itself through SetFromKey or SetFromKeys.
+func implementsKeySetter(fv reflect.Value) bool {
+	if !fv.CanAddr() {
+		return false
+	}
+	switch fv.Addr().Interface().(type) {
+	case interface{ SetFromKey(string) error }, interface{ SetFromKeys([]string) error }:
+		return true
+	}
+	return false
+}
+
+// formIndex is one element of a slice submitted with indexed names.
+type formIndex struct {
+	index int
+	key   string // e.g. "Items.0" or "Items[0]"
+}
+
+// formIndexes collects the element indexes submitted for key, in ascending order.
+// Both dotted (Items.0.Name) and bracketed (Items[0].Name) names are recognised;
+// gaps left by removed rows are ignored.
+func formIndexes(r *http.Request, key string) []formIndex {
+	seen := map[int]string{}
+	collect := func(name string) {
+		var digits, elemKey string
+		switch {
+		case strings.HasPrefix(name, key+"."):
+			rest := name[len(key)+1:]
+			if end := strings.IndexByte(rest, '.'); end >= 0 {
+				rest = rest[:end]
+			}
+			digits, elemKey = rest, key+"."+rest
+		case strings.HasPrefix(name, key+"["):
+			rest := name[len(key)+1:]
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return
+			}
+			digits, elemKey = rest[:end], key+"["+rest[:end]+"]"
+		default:
+			return
+		}
+		idx, err := strconv.Atoi(digits)
+		if err != nil || idx < 0 {
+			return
+		}
+		if _, ok := seen[idx]; !ok {
+			seen[idx] = elemKey
+		}
+	}
+
+	for name := range r.Form {
+		collect(name)
+	}
+	if r.MultipartForm != nil {
+		for name := range r.MultipartForm.File {
+			collect(name)
+		}
+	}
+
+	out := make([]formIndex, 0, len(seen))
+	for idx, elemKey := range seen {
+		out = append(out, formIndex{index: idx, key: elemKey})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].index < out[j].index })
+	return out
+}
+
+// mapSlice binds a slice of structs or scalars. The slice is left untouched when the
+// request holds no values for it, unless the request carries the empty input named key
+// that the repeater template writes: then every row was removed and the slice is
+// emptied.
+func mapSlice(r *http.Request, fv reflect.Value, key string, fail func(field, key string, args ...any), bindErrs *[]BindError) {
+	if r.Form == nil {
+		// Populates r.Form for both urlencoded and multipart bodies.
+		_ = r.ParseMultipartForm(DefaultMultipartMemory)
+	}
+	indexes := formIndexes(r, key)
+
+	elemType := fv.Type().Elem()
+	if structType := sliceStructElem(elemType); structType != nil {
+		if len(indexes) == 0 {
+			if _, rendered := r.Form[key]; rendered {
+				fv.Set(reflect.MakeSlice(fv.Type(), 0, 0))
+			}
+			return
+		}
+		out := reflect.MakeSlice(fv.Type(), 0, len(indexes))
+		for _, idx := range indexes {
+			elem := reflect.New(structType)
+			_ = mapForm(r, elem.Interface(), idx.key+".", bindErrs)
+			if elemType.Kind() == reflect.Ptr {
+				out = reflect.Append(out, elem)
+			} else {
+				out = reflect.Append(out, elem.Elem())
+			}
+		}
+		fv.Set(out)
+		return
+	}
+
+	type submitted struct {
+		key   string
+		value string
+	}
+	var values []submitted
+	for _, v := range r.Form[key] {
+		values = append(values, submitted{key: key, value: v})
+	}
+	for _, idx := range indexes {
+		for _, v := range r.Form[idx.key] {
+			values = append(values, submitted{key: idx.key, value: v})
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	out := reflect.MakeSlice(fv.Type(), 0, len(values))
+	for _, v := range values {
+		// Blank rows are dropped rather than bound as zero values.
+		if v.value == "" {
+			continue
+		}
+		elem := reflect.New(elemType).Elem()
+		if failKey := setScalarValue(elem, v.value); failKey != "" {
+			fail(v.key, failKey, v.value)
+			continue
+		}
+		out = reflect.Append(out, elem)
+	}
+	fv.Set(out)
+}
+
+// parseUUIDToFieldValue fills a [16]byte value from its canonical string form.
+func parseUUIDToFieldValue(fv reflect.Value, formValue string) bool {
+	if len(formValue) != 36 {
+		return false
+	}
+	hexString := strings.ReplaceAll(formValue, "-", "")
+	if len(hexString) != 32 {
+		return false
+	}
+	var parsed [16]byte
+	for i := range parsed {
+		// Convert each pair of hex chars to byte
+		b, err := strconv.ParseUint(hexString[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return false
+		}
+		parsed[i] = byte(b)
+	}
+	for i := range parsed {
+		fv.Index(i).SetUint(uint64(parsed[i]))
+	}
+	return true
+}
+
 func parseTimeToFieldValue(fv reflect.Value, field reflect.StructField, formValue string) error {
 	fieldTag := field.Tag.Get("form")
 	timetype := "datetime-local"
@@ -203,8 +501,7 @@ func parseTimeToFieldValue(fv reflect.Value, field reflect.StructField, formValu
 			layout = "2006-W01"
 			tt, err := WeekStringToTime(formValue)
 			if err != nil {
-				fmt.Println("Error parsing week string:", err)
-				return err // or optionally return err
+				return err
 			}
 
 			layout = "2006-01-02"
@@ -224,7 +521,11 @@ func parseTimeToFieldValue(fv reflect.Value, field reflect.StructField, formValu
 			}
 		}
 
-		fv.Set(reflect.ValueOf(parsed))
+		if fv.Kind() == reflect.Ptr {
+			fv.Set(reflect.ValueOf(&parsed))
+		} else {
+			fv.Set(reflect.ValueOf(parsed))
+		}
 	}
 	return nil
 }
@@ -1,9 +1,11 @@
 package form
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 )
 
 type testStruct struct {
@@ -171,3 +173,67 @@ func TestMapFormCheckboxOn(t *testing.T) {
 		t.Errorf("expected Marketing to be false when not present in form, got true")
 	}
 }
+
+type bindStruct struct {
+	Age      int
+	Score    float64
+	Active   bool
+	Birthday time.Time `form:"input,date"`
+	Address  struct {
+		Zip int
+	}
+}
+
+func TestMapFormWithErrors(t *testing.T) {
+	form := url.Values{}
+	form.Set("Age", "abc")
+	form.Set("Score", "1.5")
+	form.Set("Active", "maybe")
+	form.Set("Birthday", "not-a-date")
+	form.Set("Address.Zip", "12a")
+
+	r := &http.Request{Form: form}
+
+	var s bindStruct
+	errs, err := MapFormWithErrors(r, &s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := scanError(errs)
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 binding errors, got %d: %v", len(errs), got)
+	}
+	if msgs := got["Age"]; len(msgs) != 1 || msgs[0] != fmt.Sprintf(TranslationKeyBindNumber, "abc") {
+		t.Errorf("unexpected Age errors: %v", msgs)
+	}
+	if len(got["Active"]) != 1 || len(got["Birthday"]) != 1 || len(got["Address.Zip"]) != 1 {
+		t.Errorf("missing binding errors: %v", got)
+	}
+	if s.Score != 1.5 {
+		t.Errorf("expected valid Score to be bound, got %f", s.Score)
+	}
+}
+
+func TestFormBindTranslatesErrors(t *testing.T) {
+	f := NewTranslatedForm(func(_ Localizer, key string, args ...any) string {
+		return "translated:" + fmt.Sprintf(key, args...)
+	})
+
+	form := url.Values{}
+	form.Set("Age", "abc")
+	r := &http.Request{Form: form}
+
+	var s bindStruct
+	errs, err := f.Bind(r, &s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(errs))
+	}
+	field, msg := errs[0].FieldError()
+	if field != "Age" || msg != "translated:"+fmt.Sprintf(TranslationKeyBindNumber, "abc") {
+		t.Errorf("unexpected error %q: %q", field, msg)
+	}
+}
@@ -0,0 +1,104 @@
+package form
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type namespaceAddress struct {
+	Street  string `form:"input,text" label:"Street" required:"true"`
+	Zip     int    `form:"input,number" label:"Zip"`
+	Billing bool   `form:"checkbox" label:"Same as billing"`
+}
+
+type namespaceForm struct {
+	Info
+	Address namespaceAddress `legend:"Address"`
+	Tags    []string         `form:"input,text" label:"Tags"`
+}
+
+func TestNewTransformerWithNamespace(t *testing.T) {
+	tr, err := NewTransformerWithNamespace(namespaceForm{Tags: []string{"a"}}, "orders.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := tr.Fields[1]
+	if address.Name != "orders.3.Address" || address.Fields[0].Name != "orders.3.Address.Street" || address.Fields[0].Id != "orders.3.Address.Street" {
+		t.Errorf("unexpected names: %q, %q", address.Name, address.Fields[0].Name)
+	}
+	if tags := tr.Fields[2]; tags.Fields[0].Name != "orders.3.Tags.0" {
+		t.Errorf("unexpected slice row name %q", tags.Fields[0].Name)
+	}
+
+	// A bool named like the namespace is still a checkbox, not a struct radio option.
+	type Flags struct {
+		Billing bool `form:"checkbox" label:"Billing"`
+	}
+	tr, err = NewTransformerWithNamespace(Flags{}, "Billing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := tr.Fields[0]; f.Type != "checkbox" || f.Name != "Billing.Billing" {
+		t.Errorf("unexpected field %+v", f)
+	}
+}
+
+func TestFormRender_NamespaceRoundTrip(t *testing.T) {
+	f := NewForm()
+	model := namespaceForm{Info: Info{Target: "/"}}
+
+	var page strings.Builder
+	for _, ns := range []string{"billing", "shipping"} {
+		html, err := f.formRender(model, nil, "namespace", ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page.WriteString(string(html))
+	}
+	out := page.String()
+
+	seen := map[string]bool{}
+	for _, m := range regexp.MustCompile(` id="([^"]+)"`).FindAllStringSubmatch(out, -1) {
+		if seen[m[1]] {
+			t.Errorf("duplicate id %q", m[1])
+		}
+		seen[m[1]] = true
+	}
+	for _, want := range []string{`name="billing.Address.Street"`, `for="shipping.Address.Street"`, `id="shipping.Address.Street"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+
+	// The rendered names bind back with the namespace as MapForm prefix.
+	r := &http.Request{Form: url.Values{
+		"billing.Address.Street":  {"Main 1"},
+		"billing.Address.Zip":     {"12x"},
+		"shipping.Address.Street": {"Side 2"},
+	}}
+	var billing, shipping namespaceForm
+	if err := MapForm(r, &shipping, "shipping."); err != nil {
+		t.Fatal(err)
+	}
+	bindErrs, err := f.Bind(r, &billing, "billing.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if billing.Address.Street != "Main 1" || shipping.Address.Street != "Side 2" {
+		t.Errorf("unexpected binding: %+v / %+v", billing.Address, shipping.Address)
+	}
+
+	// Binding errors carry the namespace, validation errors do not; both are shown.
+	errs := append(bindErrs, f.ValidateForm(&shipping)...)
+	errs = append(errs, FieldValidationError{Field: "Address.Street", Err: "street is taken"})
+	html, err := f.formRender(billing, errs, "namespace", "billing", "fields", "Address.Zip,Address.Street")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), "street is taken") || strings.Count(string(html), `role="alert"`) < 2 {
+		t.Errorf("expected bind and validation errors next to the namespaced fields: %s", html)
+	}
+}
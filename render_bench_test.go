@@ -0,0 +1,85 @@
+package form
+
+import (
+	"html/template"
+	"io"
+	"testing"
+)
+
+// benchForm has 30 rendered fields, roughly the size of a busy admin form.
+type benchForm struct {
+	Info
+	Name1, Name2, Name3, Name4, Name5, Name6, Name7, Name8, Name9, Name10 string `form:"input,text" label:"Name" placeholder:"Name"`
+	Age1, Age2, Age3, Age4, Age5, Age6, Age7, Age8, Age9, Age10           int    `form:"input,number" label:"Age" min:"0" max:"150"`
+	Ok1, Ok2, Ok3, Ok4, Ok5, Ok6, Ok7, Ok8, Ok9, Ok10                     bool   `form:"checkbox" label:"OK"`
+}
+
+func benchmarkFormRender(b *testing.B, theme string) {
+	f := NewForm()
+	f.SetTheme(theme)
+	model := benchForm{Info: Info{Target: "/save", Method: "post"}}
+	errs := FieldErrors{FieldValidationError{Field: "Name1", Err: "required"}}
+
+	if _, err := f.formRender(model, errs); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.formRender(model, errs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFormRenderBootstrap(b *testing.B) { benchmarkFormRender(b, "bootstrap") }
+func BenchmarkFormRenderPlain(b *testing.B)     { benchmarkFormRender(b, "plain") }
+
+func BenchmarkFormRenderParallel(b *testing.B) {
+	f := NewForm()
+	model := benchForm{Info: Info{Target: "/save", Method: "post"}}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := f.formRender(model, nil); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkThemeTemplateClone measures what every label, control and wrapper used to
+// cost before theme templates were shared between renders.
+func BenchmarkThemeTemplateClone(b *testing.B) {
+	f := NewForm()
+	theme, err := f.getTheme()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		cl, err := theme.Templates.Clone()
+		if err != nil {
+			b.Fatal(err)
+		}
+		cl.Funcs(template.FuncMap{"form_print": f.themePrint})
+	}
+}
+
+// settingsForm renders hundreds of fields, like a large settings page.
+type settingsForm struct {
+	Info
+	Values []string `form:"input,text" label:"Setting"`
+}
+
+func BenchmarkRenderLargeForm(b *testing.B) {
+	f := NewForm()
+	model := settingsForm{Info: Info{Target: "/settings", Method: "post"}, Values: make([]string, 300)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := f.Render(io.Discard, nil, model, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
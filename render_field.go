@@ -0,0 +1,186 @@
+package form
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+// RenderField writes the field of model called name to w, with its label, errors and
+// the theme's wrapper, exactly as Render places it inside the form. Nested fields are
+// named by their path, such as "Address.Street"; a group or repeater renders with all
+// of its fields. This lets a page template lay out fields itself; the page then writes
+// the form element and uses RenderSubmit for the buttons.
+//
+// Of the options, Theme, Variant, Namespace and IDPrefix apply. The name is given
+// without the namespace.
+func (f *Form) RenderField(w io.Writer, loc Localizer, model any, name string, errs FieldErrors, opts RenderOptions) error {
+	return f.renderField(w, loc, model, name, errs, opts, false)
+}
+
+// RenderSubmit writes the buttons of the form for model to w: the cancel link when
+// the model's Info has a CancelTarget and the submit button with its SubmitText. The
+// CSRF input of the Info is written before the buttons, since a page that uses
+// RenderField writes the form element itself.
+//
+// Of the options, Theme and Variant apply.
+func (f *Form) RenderSubmit(w io.Writer, loc Localizer, model any, opts RenderOptions) error {
+	if err := opts.checkPart("form_submit"); err != nil {
+		return err
+	}
+	theme, ok, err := f.renderTheme(w, opts)
+	if !ok {
+		return err
+	}
+
+	tr, err := NewTransformer(model)
+	if err != nil {
+		return err
+	}
+	formField := formFieldFromInfo(Info{})
+	for _, field := range tr.Fields {
+		if field.Type == types.FieldTypeForm {
+			formField = field
+			break
+		}
+	}
+
+	return f.themeExec(w, theme, "submit", themeData{
+		Field:  formField,
+		Loc:    f.printLoc(loc),
+		Fields: csrfHiddenInputHTML(model),
+	})
+}
+
+// RenderErrors writes the messages in errs for the field called name to w with the
+// theme's error template. Nothing is written when the field has no errors.
+//
+// Of the options, Theme, Variant and Namespace apply. The name is given without the
+// namespace.
+func (f *Form) RenderErrors(w io.Writer, loc Localizer, errs FieldErrors, name string, opts RenderOptions) error {
+	if err := opts.checkPart("form_errors"); err != nil {
+		return err
+	}
+	msgs := opts.namespaceErrors(scanError(errs))[opts.qualify(name)]
+	if len(msgs) == 0 {
+		return nil
+	}
+	theme, ok, err := f.renderTheme(w, opts)
+	if !ok {
+		return err
+	}
+	return f.themeExec(w, theme, "error", themeData{
+		Loc:    f.printLoc(loc),
+		Errors: msgs,
+	})
+}
+
+// renderField renders one field of model through themeElement. With group set the
+// field must be a group or repeater.
+func (f *Form) renderField(w io.Writer, loc Localizer, model any, name string, errs FieldErrors, opts RenderOptions, group bool) error {
+	helper := "form_field"
+	if group {
+		helper = "form_group"
+	}
+	if err := opts.checkPart(helper); err != nil {
+		return err
+	}
+	theme, ok, err := f.renderTheme(w, opts)
+	if !ok {
+		return err
+	}
+
+	tr, err := NewTransformerWithNamespace(model, opts.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := f.checkModelTags(model); err != nil {
+		return err
+	}
+	field, found := findField(tr.Fields, opts.qualify(name))
+	if !found {
+		return fmt.Errorf("%s: unknown field %q", helper, name)
+	}
+	if group && field.Type != types.FieldTypeGroup && field.Type != types.FieldTypeRepeater {
+		return fmt.Errorf("%s: field %q is not a group", helper, name)
+	}
+	if opts.IDPrefix != "" {
+		fields := []types.FormField{field}
+		prefixIDs(fields, opts.IDPrefix)
+		field = fields[0]
+	}
+
+	return f.themeElement(w, theme, f.printLoc(loc), field, opts.namespaceErrors(scanError(errs)))
+}
+
+// findField returns the field called name, searching groups and repeater rows.
+func findField(fields []types.FormField, name string) (types.FormField, bool) {
+	for _, field := range fields {
+		if field.Type == types.FieldTypeForm {
+			continue
+		}
+		if field.Name == name {
+			return field, true
+		}
+		if found, ok := findField(field.Fields, name); ok {
+			return found, true
+		}
+	}
+	return types.FormField{}, false
+}
+
+func (f *Form) formField(v any, name string, errs FieldErrors, kv ...any) (template.HTML, error) {
+	return f.formFieldLocalized(&DefaultLocalizer{}, v, name, errs, kv...)
+}
+
+func (f *Form) formFieldLocalized(loc types.Localizer, v any, name string, errs FieldErrors, kv ...any) (template.HTML, error) {
+	return f.partHTML(kv, func(w io.Writer, opts RenderOptions) error {
+		return f.renderField(w, loc, v, name, errs, opts, false)
+	})
+}
+
+func (f *Form) formGroup(v any, name string, errs FieldErrors, kv ...any) (template.HTML, error) {
+	return f.formGroupLocalized(&DefaultLocalizer{}, v, name, errs, kv...)
+}
+
+func (f *Form) formGroupLocalized(loc types.Localizer, v any, name string, errs FieldErrors, kv ...any) (template.HTML, error) {
+	return f.partHTML(kv, func(w io.Writer, opts RenderOptions) error {
+		return f.renderField(w, loc, v, name, errs, opts, true)
+	})
+}
+
+func (f *Form) formErrors(errs FieldErrors, name string, kv ...any) (template.HTML, error) {
+	return f.formErrorsLocalized(&DefaultLocalizer{}, errs, name, kv...)
+}
+
+func (f *Form) formErrorsLocalized(loc types.Localizer, errs FieldErrors, name string, kv ...any) (template.HTML, error) {
+	return f.partHTML(kv, func(w io.Writer, opts RenderOptions) error {
+		return f.RenderErrors(w, loc, errs, name, opts)
+	})
+}
+
+func (f *Form) formSubmit(v any, kv ...any) (template.HTML, error) {
+	return f.formSubmitLocalized(&DefaultLocalizer{}, v, kv...)
+}
+
+func (f *Form) formSubmitLocalized(loc types.Localizer, v any, kv ...any) (template.HTML, error) {
+	return f.partHTML(kv, func(w io.Writer, opts RenderOptions) error {
+		return f.RenderSubmit(w, loc, v, opts)
+	})
+}
+
+// partHTML parses the options of a template helper and renders into a pooled buffer.
+func (f *Form) partHTML(kv []any, render func(w io.Writer, opts RenderOptions) error) (template.HTML, error) {
+	opts, err := parseRenderOptions(kv)
+	if err != nil {
+		return "", err
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := render(buf, opts); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
@@ -0,0 +1,117 @@
+package form
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestFuncMap_FieldHelpers(t *testing.T) {
+	f := NewForm()
+	model := renderOptionsForm{Info: Info{Target: "/save", SubmitText: "Save", CancelTarget: "/", CsrfValue: "tok"}}
+	errs := FieldErrors{
+		FieldValidationError{Field: "Address.City", Err: "city is required"},
+		FieldValidationError{Field: "Name", Err: "name is required"},
+	}
+
+	page := template.Must(template.New("page").Funcs(f.FuncMap()).Parse(`<form method="post" action="/save">
+<div class="left">{{ form_field .Model "Name" .Errors }}</div>
+<div class="right">{{ form_group .Model "Address" .Errors }}</div>
+<p class="summary">{{ form_errors .Errors "Name" }}</p>
+{{ form_submit .Model }}
+</form>`))
+
+	var buf bytes.Buffer
+	if err := page.Execute(&buf, map[string]any{"Model": model, "Errors": errs}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	left := out[strings.Index(out, `class="left"`):strings.Index(out, `class="right"`)]
+	if !strings.Contains(left, `name="Name"`) || strings.Contains(left, `name="Email"`) || !strings.Contains(left, "name is required") {
+		t.Errorf("unexpected field markup: %s", left)
+	}
+	right := out[strings.Index(out, `class="right"`):strings.Index(out, `class="summary"`)]
+	for _, want := range []string{`id="Address_legend"`, `name="Address.Street"`, `name="Address.City"`, "city is required"} {
+		if !strings.Contains(right, want) {
+			t.Errorf("missing %q in group markup: %s", want, right)
+		}
+	}
+	if summary := out[strings.Index(out, `class="summary"`):]; !strings.Contains(summary[:strings.Index(summary, "</p>")], "name is required") {
+		t.Errorf("missing error summary: %s", summary)
+	}
+	for _, want := range []string{`type="submit"`, ">Save<", `href="/"`, `value="tok"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %s", want, out)
+		}
+	}
+	if strings.Count(out, "<form") != 1 {
+		t.Errorf("helpers must not render a form element: %s", out)
+	}
+}
+
+func TestFormField_Options(t *testing.T) {
+	f := NewForm()
+	model := renderOptionsForm{}
+	errs := FieldErrors{FieldValidationError{Field: "Address.Street", Err: "street is required"}}
+
+	html, err := f.formField(model, "Address.Street", errs, "namespace", "billing", "idPrefix", "b-", "theme", "bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(html)
+	for _, want := range []string{`name="billing.Address.Street"`, `id="b-billing.Address.Street"`, "street is required", `class="form-control`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %s", want, out)
+		}
+	}
+
+	html, err = f.formErrors(errs, "Address.Street", "namespace", "billing")
+	if err != nil || !strings.Contains(string(html), "street is required") {
+		t.Errorf("form_errors with namespace: %q, %v", html, err)
+	}
+	if html, err := f.formErrors(errs, "Name"); err != nil || html != "" {
+		t.Errorf("form_errors without errors: %q, %v", html, err)
+	}
+
+	if html, err := f.formSubmit(model); err != nil || !strings.Contains(string(html), ">Submit<") {
+		t.Errorf("form_submit without Info text: %q, %v", html, err)
+	}
+
+	for name, fn := range map[string]func() (template.HTML, error){
+		"unknown field":  func() (template.HTML, error) { return f.formField(model, "Missing", nil) },
+		"not a group":    func() (template.HTML, error) { return f.formGroup(model, "Name", nil) },
+		"fields option":  func() (template.HTML, error) { return f.formField(model, "Name", nil, "fields", "Email") },
+		"readOnly":       func() (template.HTML, error) { return f.formSubmit(model, "readOnly", true) },
+		"unknown option": func() (template.HTML, error) { return f.formErrors(nil, "Name", "colour", "red") },
+	} {
+		if _, err := fn(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFuncMap_LocalizedFieldHelpers(t *testing.T) {
+	f := NewTranslatedForm(func(loc Localizer, key string, _ ...any) string {
+		return loc.GetLocale() + ":" + key
+	})
+	model := renderOptionsForm{Info: Info{Target: "/save", SubmitText: "Save"}}
+	errs := FieldErrors{FieldValidationError{Field: "Name", Err: "name is required"}}
+
+	page := template.Must(template.New("page").Funcs(f.FuncMap()).Parse(`{{ form_field_localized .Loc .Model "Name" .Errors }}
+{{ form_group_localized .Loc .Model "Address" .Errors }}
+{{ form_errors_localized .Loc .Errors "Name" }}
+{{ form_submit_localized .Loc .Model }}`))
+
+	var buf bytes.Buffer
+	if err := page.Execute(&buf, map[string]any{"Loc": testLocalizer{Locale: "it"}, "Model": model, "Errors": errs}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"it:Name", "it:Address", "it:Save"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %s", want, out)
+		}
+	}
+}
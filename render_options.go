@@ -0,0 +1,225 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+// RenderOptions changes how a single render looks without changing the model, so
+// one struct can drive several slightly different forms on a page. Pass it to
+// RenderWithOptions, or as the last argument of form_render.
+//
+// form_render also accepts the options as key/value pairs after the errors:
+//
+//	{{ form_render .Form nil "theme" "tailwind" "idPrefix" "billing-" "fields" "Name,Email" }}
+//
+// The keys are "theme", "variant", "namespace", "idPrefix", "hideSubmit", "fields" (a []string or
+// a comma separated string), "readOnly", "attributes" (a map[string]string) and
+// "attr.<name>" for a single form attribute.
+type RenderOptions struct {
+	Theme      string            // Theme name instead of the Form's theme, e.g. "tailwind" or "bootstrap:dark"
+	Variant    string            // Variant of the theme, e.g. "dark"
+	Namespace  string            // Place every field id and name under this name, e.g. "billing" renders billing.Street
+	IDPrefix   string            // Prefix for every element id, so a model can render twice on a page
+	HideSubmit bool              // Leave out the submit button
+	Fields     []string          // Render only these fields, by name without the namespace; nested names such as "Address.City" select inside groups
+	ReadOnly   bool              // Disable every control and leave out the submit button
+	Attributes map[string]string // Extra attributes for the form element; they replace attributes from Info
+}
+
+// parseRenderOptions reads the options of form_render: a RenderOptions value or
+// key/value pairs. Unknown keys are an error so a typo does not silently render the
+// default form.
+func parseRenderOptions(kv []any) (RenderOptions, error) {
+	var opts RenderOptions
+	if len(kv) == 1 {
+		switch o := kv[0].(type) {
+		case RenderOptions:
+			return o, nil
+		case *RenderOptions:
+			if o != nil {
+				return *o, nil
+			}
+			return opts, nil
+		}
+	}
+	if len(kv)%2 != 0 {
+		return opts, fmt.Errorf("form_render options must be key/value pairs, got %d values", len(kv))
+	}
+
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			return opts, fmt.Errorf("form_render option key must be a string, got %T", kv[i])
+		}
+		value := kv[i+1]
+		typeErr := func() error {
+			return fmt.Errorf("form_render option %q has unsupported value %T", key, value)
+		}
+
+		if name, ok := strings.CutPrefix(key, "attr."); ok {
+			s, ok := value.(string)
+			if !ok || name == "" {
+				return opts, typeErr()
+			}
+			if opts.Attributes == nil {
+				opts.Attributes = make(map[string]string)
+			}
+			opts.Attributes[name] = s
+			continue
+		}
+
+		var valid bool
+		switch key {
+		case "theme":
+			opts.Theme, valid = value.(string)
+		case "variant":
+			opts.Variant, valid = value.(string)
+		case "namespace":
+			opts.Namespace, valid = value.(string)
+		case "idPrefix":
+			opts.IDPrefix, valid = value.(string)
+		case "hideSubmit":
+			opts.HideSubmit, valid = value.(bool)
+		case "readOnly":
+			opts.ReadOnly, valid = value.(bool)
+		case "fields":
+			switch v := value.(type) {
+			case []string:
+				opts.Fields, valid = v, true
+			case string:
+				for _, name := range strings.Split(v, ",") {
+					if name = strings.TrimSpace(name); name != "" {
+						opts.Fields = append(opts.Fields, name)
+					}
+				}
+				valid = true
+			}
+		case "attributes":
+			var attrs map[string]string
+			attrs, valid = value.(map[string]string)
+			for k, v := range attrs {
+				if opts.Attributes == nil {
+					opts.Attributes = make(map[string]string)
+				}
+				opts.Attributes[k] = v
+			}
+		default:
+			return opts, fmt.Errorf("unknown form_render option %q", key)
+		}
+		if !valid {
+			return opts, typeErr()
+		}
+	}
+	return opts, nil
+}
+
+// themeName returns the name of the theme to render with.
+func (o RenderOptions) themeName(selected string) string {
+	name := selected
+	if o.Theme != "" {
+		name = o.Theme
+	}
+	if o.Variant == "" {
+		return name
+	}
+	return name + ":" + o.Variant
+}
+
+// qualify places a field name given without the namespace under it.
+func (o RenderOptions) qualify(name string) string {
+	if o.Namespace == "" || name == "" || strings.HasPrefix(name, o.Namespace+".") {
+		return name
+	}
+	return o.Namespace + "." + name
+}
+
+// checkPart reports options that only apply to a whole form, for the helpers that
+// render a part of it.
+func (o RenderOptions) checkPart(helper string) error {
+	switch {
+	case len(o.Fields) > 0:
+		return fmt.Errorf("%s does not support the fields option", helper)
+	case o.HideSubmit:
+		return fmt.Errorf("%s does not support the hideSubmit option", helper)
+	case o.ReadOnly:
+		return fmt.Errorf("%s does not support the readOnly option", helper)
+	case len(o.Attributes) > 0:
+		return fmt.Errorf("%s does not support form attributes", helper)
+	}
+	return nil
+}
+
+// apply adjusts the transformed fields to the options.
+func (o RenderOptions) apply(fields []types.FormField) ([]types.FormField, error) {
+	if len(o.Fields) > 0 {
+		want := make(map[string]bool, len(o.Fields))
+		for _, name := range o.Fields {
+			want[o.qualify(name)] = false
+		}
+		fields = selectFields(fields, want)
+		for name, found := range want {
+			if !found {
+				return nil, fmt.Errorf("render option fields: unknown field %q", name)
+			}
+		}
+	}
+	if o.IDPrefix != "" {
+		prefixIDs(fields, o.IDPrefix)
+	}
+	return fields, nil
+}
+
+// selectFields keeps the fields whose name is in want and the groups that hold one,
+// marking every name that was found. The form metadata is always kept.
+func selectFields(fields []types.FormField, want map[string]bool) []types.FormField {
+	var out []types.FormField
+	for _, field := range fields {
+		if _, ok := want[field.Name]; ok {
+			want[field.Name] = true
+			out = append(out, field)
+			continue
+		}
+		if field.Type == types.FieldTypeForm {
+			out = append(out, field)
+			continue
+		}
+		if field.Type == types.FieldTypeGroup {
+			if sub := selectFields(field.Fields, want); len(sub) > 0 {
+				field.Fields = sub
+				out = append(out, field)
+			}
+		}
+	}
+	return out
+}
+
+// prefixIDs prepends prefix to the id of every field. Names are left alone, so the
+// submitted form binds as usual.
+func prefixIDs(fields []types.FormField, prefix string) {
+	for i := range fields {
+		if fields[i].Id != "" {
+			fields[i].Id = prefix + fields[i].Id
+		}
+		prefixIDs(fields[i].Fields, prefix)
+		prefixIDs(fields[i].Prototype, prefix)
+	}
+}
+
+// namespaceErrors places the field errors that are not yet under the namespace under
+// it. Bind and MapFormWithErrors with the namespace prefix report namespaced fields,
+// ValidateForm reports the model's own field names; both end up next to their field.
+// Form errors stay form errors.
+func (o RenderOptions) namespaceErrors(errs map[string][]string) map[string][]string {
+	if o.Namespace == "" {
+		return errs
+	}
+	out := make(map[string][]string, len(errs))
+	for field, msgs := range errs {
+		field = o.qualify(field)
+		out[field] = append(out[field], msgs...)
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package form
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+type renderOptionsAddress struct {
+	Street string `form:"input,text" label:"Street"`
+	City   string `form:"input,text" label:"City"`
+}
+
+type renderOptionsForm struct {
+	Info
+	Name    string               `form:"input,text" label:"Name"`
+	Email   string               `form:"input,email" label:"Email"`
+	Address renderOptionsAddress `legend:"Address"`
+}
+
+func TestParseRenderOptions(t *testing.T) {
+	opts, err := parseRenderOptions([]any{
+		"theme", "plain",
+		"idPrefix", "b-",
+		"hideSubmit", true,
+		"fields", "Name, Email",
+		"attr.hx-post", "/save",
+		"attributes", map[string]string{"data-x": "1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Theme != "plain" || opts.IDPrefix != "b-" || !opts.HideSubmit || len(opts.Fields) != 2 || opts.Fields[1] != "Email" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts.Attributes["hx-post"] != "/save" || opts.Attributes["data-x"] != "1" {
+		t.Errorf("unexpected attributes: %v", opts.Attributes)
+	}
+
+	typed := RenderOptions{ReadOnly: true}
+	if got, err := parseRenderOptions([]any{typed}); err != nil || !got.ReadOnly {
+		t.Errorf("typed options: %+v, %v", got, err)
+	}
+	if got, err := parseRenderOptions([]any{&typed}); err != nil || !got.ReadOnly {
+		t.Errorf("typed options pointer: %+v, %v", got, err)
+	}
+
+	for _, kv := range [][]any{{"hideSubmit", "yes"}, {"fields", 3}, {"attr.", "x"}, {1, "x"}} {
+		if _, err := parseRenderOptions(kv); err == nil {
+			t.Errorf("%v: expected error", kv)
+		}
+	}
+}
+
+func TestFormRender_RenderOptions(t *testing.T) {
+	f := NewForm()
+	model := renderOptionsForm{Info: Info{Target: "/", Attributes: map[string]string{"data-x": "model"}}}
+
+	html, err := f.formRender(model, nil,
+		"idPrefix", "billing-",
+		"fields", "Name,Address.City",
+		"hideSubmit", true,
+		"attr.data-x", "option",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(html)
+	for _, want := range []string{`id="billing-Name"`, `name="Name"`, `for="billing-Name"`, `id="billing-Address.City"`, `data-x="option"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %s", want, out)
+		}
+	}
+	for _, unwanted := range []string{`name="Email"`, `name="Address.Street"`, `type="submit"`} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("unexpected %q in %s", unwanted, out)
+		}
+	}
+	if model.Attributes["data-x"] != "model" {
+		t.Error("render options changed the model's attributes")
+	}
+
+	if _, err := f.formRender(model, nil, "fields", "Nmae"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestFormRender_ReadOnlyAndTheme(t *testing.T) {
+	f := NewForm()
+	f.SetTheme("bootstrap")
+	model := renderOptionsForm{Info: Info{Target: "/"}}
+
+	tmpl := template.Must(template.New("page").Funcs(f.FuncMap()).Parse(`{{ form_render .Form nil .Opts }}`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{"Form": model, "Opts": RenderOptions{ReadOnly: true, Theme: "plain"}}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "<fieldset disabled") || strings.Contains(out, `type="submit"`) {
+		t.Errorf("expected a disabled form without submit: %s", out)
+	}
+	if strings.Contains(out, "form-control") {
+		t.Errorf("expected the plain theme: %s", out)
+	}
+
+	buf.Reset()
+	if err := f.RenderWithOptions(&buf, nil, model, nil, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `type="submit"`) || strings.Contains(buf.String(), "fieldset") {
+		t.Errorf("default render changed: %s", buf.String())
+	}
+}
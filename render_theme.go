@@ -1,38 +1,120 @@
 package form
 
 import (
+	"bytes"
+	"fmt"
 	"html/template"
-	"strings"
+	"io"
+	"sync"
 
 	"github.com/donseba/go-form/v2/templates"
 	"github.com/donseba/go-form/v2/types"
 )
 
-// themeExec clones a theme template set, injects standard form functions, and executes one template.
-//
-// Contract:
-// - It always injects form_print wired to this Form's translation function.
-// - It can inject optional overrides (fields/errors/label/field etc) via funcs.
-// - It returns template.HTML and preserves template execution errors.
-func (f *Form) themeExec(theme *templates.Theme, tmplName string, data any, funcs template.FuncMap) (template.HTML, error) {
-	cl, err := theme.Templates.Clone()
-	if err != nil {
-		return "", err
+// themeData is the data passed to every theme template. Content that depends on the
+// render, such as nested fields or error messages, travels here rather than through
+// template funcs, so a theme's template set is parsed once and never cloned.
+type themeData struct {
+	Field types.FormField
+	Loc   types.Localizer
+
+	Type      string          // input: the input type
+	Label     template.HTML   // wrapper: rendered label
+	Control   template.HTML   // wrapper: rendered control
+	Fields    template.HTML   // form, group: rendered child fields; submit: the CSRF input
+	Errors    []string        // wrapper, group, repeater: messages for this field
+	Rows      []template.HTML // repeater: rendered rows
+	Prototype template.HTML   // repeater: rendered blank row
+
+	ErrorSummary template.HTML            // form: rendered error summary
+	Summary      []types.ErrorSummaryItem // error-summary: every error of the form
+	HideSubmit   bool                     // form: leave out the submit button
+	ReadOnly     bool                     // form: disable every control
+}
+
+// renderLoc wraps the caller's Localizer so form_print translates with this Form's
+// translation function. It implements templates.Printer.
+type renderLoc struct {
+	types.Localizer
+	f *Form
+}
+
+// Print implements templates.Printer.
+func (l renderLoc) Print(key string, args ...any) string {
+	return l.f.themePrint(l.Localizer, key, args...)
+}
+
+// printLoc returns loc wrapped for use in theme data. A nil loc renders with the
+// DefaultLocalizer.
+func (f *Form) printLoc(loc types.Localizer) types.Localizer {
+	if loc == nil {
+		loc = &DefaultLocalizer{}
 	}
+	if _, ok := loc.(renderLoc); ok {
+		return loc
+	}
+	return renderLoc{Localizer: loc, f: f}
+}
+
+// maxPooledBufferSize keeps unusually large renders from pinning their buffers in
+// the pool.
+const maxPooledBufferSize = 1 << 20
 
-	// Always inject the translated form_print.
-	cl = cl.Funcs(template.FuncMap{
-		"form_print": func(loc types.Localizer, key string, args ...any) string { return f.themePrint(loc, key, args...) },
-	})
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
 
-	// Optional overrides (fields/errors/etc.).
-	if len(funcs) > 0 {
-		cl = cl.Funcs(funcs)
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
 	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// themeExec executes one template of a theme's shared template set into w.
+//
+// Contract:
+// - data.Loc should come from printLoc so form_print uses this Form's translations.
+// - Template execution errors are returned as is; w may hold partial output.
+func (f *Form) themeExec(w io.Writer, theme *templates.Theme, tmplName string, data any) error {
+	return theme.ExecuteTemplate(w, tmplName, data)
+}
 
-	var out strings.Builder
-	if err := cl.ExecuteTemplate(&out, tmplName, data); err != nil {
+// themeHTML executes one template into a pooled buffer and returns the result, for
+// output that is passed on as data to an enclosing template.
+func (f *Form) themeHTML(theme *templates.Theme, tmplName string, data any) (template.HTML, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := f.themeExec(buf, theme, tmplName, data); err != nil {
 		return "", err
 	}
-	return template.HTML(out.String()), nil
+	return template.HTML(buf.String()), nil
+}
+
+// renderTheme returns the theme to render with, re-read from disk first when hot
+// reload is enabled. When the templates fail to parse, the error is written to w in
+// place of the output and ok is false.
+func (f *Form) renderTheme(w io.Writer, opts RenderOptions) (theme *templates.Theme, ok bool, err error) {
+	theme, err = f.lookupTheme(opts.themeName(f.themeName))
+	if err != nil {
+		return nil, false, err
+	}
+	if err := theme.Reload(); err != nil {
+		return nil, false, writeTemplateError(w, theme, err)
+	}
+	return theme, true, nil
+}
+
+// writeTemplateError renders a theme template parse error in place of the form, so a
+// broken template shows up in the page during development instead of failing the
+// request. Only themes with hot reload enabled report these errors.
+func writeTemplateError(w io.Writer, theme *templates.Theme, err error) error {
+	_, werr := fmt.Fprintf(w, `<pre class="go-form-template-error" role="alert" style="color:#b00020;white-space:pre-wrap">theme %s: %s</pre>`,
+		template.HTMLEscapeString(theme.Name), template.HTMLEscapeString(err.Error()))
+	return werr
 }
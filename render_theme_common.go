@@ -1,44 +1,84 @@
 package form
 
 import (
+	"bytes"
 	"fmt"
 	"html/template"
+	"io"
 	"reflect"
+	"strings"
 
 	"github.com/donseba/go-form/v2/templates"
 	"github.com/donseba/go-form/v2/types"
 )
 
-// getTheme loads embedded themes (once) and returns the selected theme.
+// getTheme returns the selected theme from the form's own registry or, failing that,
+// from the global registry, loading the embedded themes once.
 func (f *Form) getTheme() (*templates.Theme, error) {
+	return f.lookupTheme(f.themeName)
+}
+
+// lookupTheme returns the theme called name. A name such as "bootstrap:dark" that is
+// not a theme of its own selects the "dark" variant of the "bootstrap" theme.
+func (f *Form) lookupTheme(name string) (*templates.Theme, error) {
+	if name == "" {
+		return nil, fmt.Errorf("no theme selected")
+	}
+	if theme, ok := f.themes[name]; ok {
+		if theme == nil || !theme.Loaded() {
+			return nil, fmt.Errorf("theme %q has no templates loaded", name)
+		}
+		return theme, nil
+	}
 	if err := ensureThemesLoaded(); err != nil {
 		return nil, err
 	}
-	if f.themeName == "" {
-		return nil, fmt.Errorf("no theme selected")
+	theme, ok := templates.GetTheme(name)
+	if ok && theme != nil && theme.Loaded() {
+		return theme, nil
 	}
-	theme, ok := templates.GetTheme(f.themeName)
-	if !ok || theme == nil || theme.Templates == nil {
-		return nil, fmt.Errorf("unknown or unloaded theme %q", f.themeName)
+	if base, variant, found := cutLast(name, ":"); found {
+		parent, err := f.lookupTheme(base)
+		if err != nil {
+			return nil, err
+		}
+		return parent.Variant(variant)
 	}
-	return theme, nil
+	return nil, fmt.Errorf("unknown or unloaded theme %q", name)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
 }
 
 // csrfHiddenInputHTML extracts CSRF settings from the model and returns a hidden input.
 func csrfHiddenInputHTML(v any) template.HTML {
+	info, ok := modelInfo(v)
+	if !ok {
+		return ""
+	}
+	return csrfHiddenInputHTMLFromInfo(info)
+}
+
+// modelInfo returns the form metadata of a model: the Info of a RenderModel, the
+// result of GetFormInfo or an Info embedded as the first field.
+func modelInfo(v any) (Info, bool) {
 	switch wrapped := v.(type) {
 	case RenderModel:
-		return csrfHiddenInputHTMLFromInfo(wrapped.Info)
+		return wrapped.Info, true
 	case *RenderModel:
 		if wrapped != nil {
-			return csrfHiddenInputHTMLFromInfo(wrapped.Info)
+			return wrapped.Info, true
 		}
 	}
 
 	// Prefer explicit GetFormInfo.
 	if fm, ok := v.(interface{ GetFormInfo() Info }); ok {
-		info := fm.GetFormInfo()
-		return csrfHiddenInputHTMLFromInfo(info)
+		return fm.GetFormInfo(), true
 	}
 
 	rval := reflect.ValueOf(v)
@@ -50,11 +90,11 @@ func csrfHiddenInputHTML(v any) template.HTML {
 	if rval.IsValid() && rval.Kind() == reflect.Struct && rval.NumField() > 0 {
 		firstField := rval.Field(0)
 		if firstField.IsValid() && firstField.Type() == reflect.TypeOf(Info{}) {
-			return csrfHiddenInputHTMLFromInfo(firstField.Interface().(Info))
+			return firstField.Interface().(Info), true
 		}
 	}
 
-	return ""
+	return Info{}, false
 }
 
 func csrfHiddenInputHTMLFromInfo(info Info) template.HTML {
@@ -72,16 +112,61 @@ func csrfHiddenInputHTMLFromInfo(info Info) template.HTML {
 		template.HTMLEscapeString(info.CsrfValue)))
 }
 
-// renderGroupFields renders all fields inside a group by delegating to themeFieldHTML.
-// It ignores per-field render errors (legacy behavior) to avoid nuking the whole form.
-func (f *Form) renderGroupFields(theme *templates.Theme, loc types.Localizer, fields []types.FormField, fieldErrors map[string][]string) template.HTML {
-	var sub template.HTML
+// renderGroupFields renders all fields inside a group into buf by delegating to
+// themeElement. It skips fields that fail to render (legacy behavior) to avoid
+// nuking the whole form, dropping any partial output of the failed field.
+func (f *Form) renderGroupFields(buf *bytes.Buffer, theme *templates.Theme, loc types.Localizer, fields []types.FormField, fieldErrors map[string][]string) {
 	for _, subField := range fields {
-		fh, err := f.themeFieldHTML(theme, loc, subField, fieldErrors)
-		if err != nil {
-			continue
+		n := buf.Len()
+		if err := f.themeElement(buf, theme, loc, subField, fieldErrors); err != nil {
+			buf.Truncate(n)
 		}
-		sub += fh
 	}
-	return sub
+}
+
+// groupFieldsHTML renders fields with renderGroupFields for use as template data.
+func (f *Form) groupFieldsHTML(theme *templates.Theme, loc types.Localizer, fields []types.FormField, fieldErrors map[string][]string) template.HTML {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	f.renderGroupFields(buf, theme, loc, fields, fieldErrors)
+	return template.HTML(buf.String())
+}
+
+// themeElement renders any field below the form: groups and repeaters with their
+// own templates, everything else through themeField.
+func (f *Form) themeElement(w io.Writer, theme *templates.Theme, loc types.Localizer, field types.FormField, fieldErrors map[string][]string) error {
+	switch field.Type {
+	case types.FieldTypeGroup:
+		return f.themeGroup(w, theme, loc, field, fieldErrors)
+	case types.FieldTypeRepeater:
+		return f.themeRepeater(w, theme, loc, field, fieldErrors)
+	default:
+		return f.themeField(w, theme, loc, field, fieldErrors)
+	}
+}
+
+// themeGroup renders a group field with the theme's group template.
+func (f *Form) themeGroup(w io.Writer, theme *templates.Theme, loc types.Localizer, field types.FormField, fieldErrors map[string][]string) error {
+	return f.themeExec(w, theme, "group", themeData{
+		Field:  field,
+		Loc:    loc,
+		Fields: f.groupFieldsHTML(theme, loc, field.Fields, fieldErrors),
+		Errors: fieldErrors[field.Name],
+	})
+}
+
+// themeRepeater renders a repeater: one block per existing row plus the blank
+// prototype row that the template clones when a row is added.
+func (f *Form) themeRepeater(w io.Writer, theme *templates.Theme, loc types.Localizer, field types.FormField, fieldErrors map[string][]string) error {
+	rows := make([]template.HTML, 0, len(field.Fields))
+	for _, row := range field.Fields {
+		rows = append(rows, f.groupFieldsHTML(theme, loc, row.Fields, fieldErrors))
+	}
+	return f.themeExec(w, theme, "repeater", themeData{
+		Field:     field,
+		Loc:       loc,
+		Rows:      rows,
+		Prototype: f.groupFieldsHTML(theme, loc, field.Prototype, nil),
+		Errors:    fieldErrors[field.Name],
+	})
 }
@@ -2,6 +2,7 @@ package form
 
 import (
 	"html/template"
+	"io"
 
 	"github.com/donseba/go-form/v2/templates"
 	"github.com/donseba/go-form/v2/types"
@@ -13,28 +14,25 @@ func (f *Form) themeWrapIfGrouped(theme *templates.Theme, field types.FormField,
 	if field.GroupBefore == "" && field.GroupAfter == "" {
 		return control, nil
 	}
-	return f.themeExec(theme, "input-group", map[string]any{
+	return f.themeHTML(theme, "input-group", map[string]any{
 		"GroupBefore": template.HTML(field.GroupBefore),
 		"GroupAfter":  template.HTML(field.GroupAfter),
 		"Input":       control,
-	}, nil)
+	})
 }
 
-// themeWrapField renders the wrapper template using the provided label and control HTML.
-func (f *Form) themeWrapField(theme *templates.Theme, loc types.Localizer, field types.FormField, label template.HTML, control template.HTML, errorMap map[string][]string) (template.HTML, error) {
+// themeWrapField writes the wrapper template using the provided label and control HTML.
+func (f *Form) themeWrapField(w io.Writer, theme *templates.Theme, loc types.Localizer, field types.FormField, label template.HTML, control template.HTML, errorMap map[string][]string) error {
 	if field.InputType == types.InputFieldTypeHidden {
-		return control, nil
+		_, err := io.WriteString(w, string(control))
+		return err
 	}
 
-	funcs := template.FuncMap{
-		"label": func() template.HTML { return label },
-		"field": func() template.HTML { return control },
-		"errors": func() []string {
-			if es, ok := errorMap[field.Name]; ok {
-				return es
-			}
-			return nil
-		},
-	}
-	return f.themeExec(theme, "wrapper", map[string]any{"Field": field, "Loc": loc}, funcs)
+	return f.themeExec(w, theme, "wrapper", themeData{
+		Field:   field,
+		Loc:     loc,
+		Label:   label,
+		Control: control,
+		Errors:  errorMap[field.Name],
+	})
 }
@@ -0,0 +1,97 @@
+package form
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+type repeaterForm struct {
+	Info
+	Lines []orderLine `form:"repeater" label:"Line" legend:"Order lines" min:"2" max:"3"`
+}
+
+func TestTransformerRepeater(t *testing.T) {
+	tr, err := NewTransformer(repeaterForm{Lines: []orderLine{{Product: "Apple"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := tr.Fields[1]
+	if lines.Type != types.FieldTypeRepeater {
+		t.Fatalf("expected repeater, got %s", lines.Type)
+	}
+	if lines.Min != "2" || lines.Max != "3" {
+		t.Errorf("unexpected bounds %q..%q", lines.Min, lines.Max)
+	}
+	if len(lines.Fields) != 2 {
+		t.Fatalf("expected rows padded to min, got %d", len(lines.Fields))
+	}
+	if lines.Fields[1].Fields[0].Name != "Lines.1.Product" {
+		t.Errorf("unexpected padded row name %q", lines.Fields[1].Fields[0].Name)
+	}
+	if len(lines.Prototype) != 2 || lines.Prototype[0].Name != "Lines.__index__.Product" {
+		t.Errorf("unexpected prototype: %#v", lines.Prototype)
+	}
+}
+
+func TestRenderRepeater(t *testing.T) {
+	for _, theme := range []string{"bootstrap", "tailwind", "tailwindv4", "plain", "bulma", "pico", "foundation"} {
+		f := NewForm()
+		f.SetTheme(theme)
+		html, err := f.formRender(repeaterForm{
+			Info:  Info{Target: "/orders", Method: "post"},
+			Lines: []orderLine{{Product: "Apple"}, {Product: "Pear"}},
+		}, FieldErrors{FieldValidationError{Field: "Lines", Err: "too many lines"}})
+		if err != nil {
+			t.Fatalf("%s: %v", theme, err)
+		}
+		out := string(html)
+		for _, want := range []string{
+			`data-repeater="Lines"`,
+			`<input type="hidden" name="Lines" value="">`,
+			`data-repeater-max="3"`,
+			`name="Lines.1.Product"`,
+			`<template data-repeater-template>`,
+			`name="Lines.__index__.Product"`,
+			"data-repeater-add",
+			"too many lines",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s: rendered repeater is missing %q", theme, want)
+			}
+		}
+	}
+}
+
+func TestValidateRepeaterRowBounds(t *testing.T) {
+	errs := NewForm().ValidateForm(&repeaterForm{Lines: []orderLine{{Product: "Apple"}}})
+	if got := scanError(errs); len(got["Lines"]) != 1 {
+		t.Errorf("expected a min rows error, got %v", got)
+	}
+}
+
+func TestMapFormRepeaterAllRowsRemoved(t *testing.T) {
+	model := repeaterForm{Lines: []orderLine{{Product: "Apple"}, {Product: "Pear"}}}
+
+	// Only the repeater's marker input is left once every row was removed.
+	r := &http.Request{Form: url.Values{"Lines": {""}}}
+	if err := MapForm(r, &model); err != nil {
+		t.Fatal(err)
+	}
+	if model.Lines == nil || len(model.Lines) != 0 {
+		t.Errorf("expected the rows to be cleared, got %+v", model.Lines)
+	}
+
+	// Without the marker the repeater was not part of the form; keep the rows.
+	model.Lines = []orderLine{{Product: "Apple"}}
+	if err := MapForm(&http.Request{Form: url.Values{}}, &model); err != nil {
+		t.Fatal(err)
+	}
+	if len(model.Lines) != 1 {
+		t.Errorf("expected the rows to be kept, got %+v", model.Lines)
+	}
+}
@@ -0,0 +1,179 @@
+package form
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+type orderLine struct {
+	Product  string `form:"input,text" label:"Product" required:"true"`
+	Quantity int    `form:"input,number" label:"Quantity"`
+}
+
+type orderForm struct {
+	Info
+	Tags   []string    `form:"input,text" label:"Tag"`
+	Counts []int       `label:"Count"`
+	Colors []string    `form:"multicheckbox" label:"Colors" values:"r:Red;g:Green"`
+	Lines  []orderLine `label:"Line" legend:"Order line"`
+	Extra  []*orderLine
+}
+
+func TestMapFormSlicesDottedNames(t *testing.T) {
+	form := url.Values{}
+	form.Set("Tags.0", "a")
+	form.Set("Tags.1", "b")
+	form["Colors"] = []string{"r", "g"}
+	form.Set("Counts.0", "1")
+	form.Set("Counts.1", "x")
+	form.Set("Lines.1.Product", "Pear")
+	form.Set("Lines.1.Quantity", "3")
+	form.Set("Lines.0.Product", "Apple")
+	form.Set("Lines.0.Quantity", "2")
+
+	r := &http.Request{Form: form}
+
+	var s orderForm
+	errs, err := MapFormWithErrors(r, &s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(s.Tags, ",") != "a,b" {
+		t.Errorf("unexpected Tags: %v", s.Tags)
+	}
+	if strings.Join(s.Colors, ",") != "r,g" {
+		t.Errorf("unexpected Colors: %v", s.Colors)
+	}
+	if len(s.Counts) != 1 || s.Counts[0] != 1 {
+		t.Errorf("unexpected Counts: %v", s.Counts)
+	}
+	if got := scanError(errs); len(errs) != 1 || len(got["Counts.1"]) != 1 {
+		t.Errorf("expected a binding error for Counts.1, got %v", got)
+	}
+	if len(s.Lines) != 2 || s.Lines[0].Product != "Apple" || s.Lines[1].Quantity != 3 {
+		t.Errorf("unexpected Lines: %+v", s.Lines)
+	}
+}
+
+func TestMapFormSlicesBracketNames(t *testing.T) {
+	form := url.Values{}
+	form.Set("Lines[0].Product", "Apple")
+	form.Set("Lines[5].Product", "Pear")
+	form.Set("Extra[0].Quantity", "7")
+
+	r := &http.Request{Form: form}
+
+	var s orderForm
+	if err := MapForm(r, &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(s.Lines) != 2 || s.Lines[1].Product != "Pear" {
+		t.Errorf("unexpected Lines: %+v", s.Lines)
+	}
+	if len(s.Extra) != 1 || s.Extra[0] == nil || s.Extra[0].Quantity != 7 {
+		t.Errorf("unexpected Extra: %+v", s.Extra)
+	}
+}
+
+func TestTransformerSlices(t *testing.T) {
+	tr, err := NewTransformer(orderForm{
+		Tags:   []string{"a", "b"},
+		Colors: []string{"g"},
+		Lines:  []orderLine{{Product: "Apple", Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := map[string]types.FormField{}
+	for _, field := range tr.Fields {
+		fields[field.Name] = field
+	}
+
+	tags := fields["Tags"]
+	if tags.Type != types.FieldTypeGroup || len(tags.Fields) != 2 {
+		t.Fatalf("expected Tags group with two inputs, got %#v", tags)
+	}
+	if tags.Fields[1].Name != "Tags.1" || tags.Fields[1].InputType != types.InputFieldTypeText || tags.Fields[1].Value != "b" {
+		t.Errorf("unexpected tag input: %#v", tags.Fields[1])
+	}
+
+	colors := fields["Colors"]
+	if colors.Type != types.FieldTypeMultiCheckbox || !colors.ValueMap["g"] || colors.ValueMap["r"] {
+		t.Errorf("unexpected Colors field: %#v", colors)
+	}
+
+	lines := fields["Lines"]
+	if lines.Type != types.FieldTypeGroup || lines.Legend != "Order line" || len(lines.Fields) != 1 {
+		t.Fatalf("unexpected Lines field: %#v", lines)
+	}
+	row := lines.Fields[0]
+	if row.Type != types.FieldTypeGroup || row.Name != "Lines.0" || len(row.Fields) != 2 || row.Fields[0].Name != "Lines.0.Product" {
+		t.Errorf("unexpected Lines row: %#v", row)
+	}
+}
+
+func TestRenderSliceRows(t *testing.T) {
+	f := NewForm()
+	html, err := f.formRender(orderForm{
+		Info:  Info{Target: "/orders", Method: "post"},
+		Lines: []orderLine{{Product: "Apple"}, {Product: "Pear"}},
+	}, FieldErrors{FieldValidationError{Field: "Lines.1.Product", Err: "out of stock"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(html)
+	for _, want := range []string{`name="Lines.0.Product"`, `name="Lines.1.Product"`, "out of stock"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered form is missing %q", want)
+		}
+	}
+}
+
+func TestValidateFormSliceRows(t *testing.T) {
+	errs := NewForm().ValidateForm(&orderForm{
+		Lines:  []orderLine{{Product: "Apple"}, {}},
+		Colors: []string{"r", "x"},
+	})
+	got := scanError(errs)
+	if len(got["Lines.1.Product"]) != 1 {
+		t.Errorf("expected required error for Lines.1.Product, got %v", got)
+	}
+	if len(got["Colors"]) != 1 {
+		t.Errorf("expected invalid value error for Colors, got %v", got)
+	}
+}
+
+func TestMultiCheckboxCanBeCleared(t *testing.T) {
+	f := NewForm()
+	html, err := f.formRender(orderForm{Info: Info{Target: "/orders"}, Colors: []string{"r"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), `<input type="hidden" name="Colors" value="">`) {
+		t.Fatalf("multicheckbox is missing the empty hidden input:\n%s", html)
+	}
+
+	// With every box unchecked only the hidden input is submitted.
+	s := orderForm{Colors: []string{"r"}}
+	if err := MapForm(&http.Request{Form: url.Values{"Colors": {""}}}, &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Colors) != 0 {
+		t.Errorf("expected Colors to be cleared, got %v", s.Colors)
+	}
+
+	s = orderForm{}
+	if err := MapForm(&http.Request{Form: url.Values{"Colors": {"", "g"}}}, &s); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(s.Colors, ",") != "g" {
+		t.Errorf("unexpected Colors: %v", s.Colors)
+	}
+}
@@ -116,3 +116,26 @@ func TestSortedMultiSelect_MapForm_InvalidValue(t *testing.T) {
 		t.Errorf("Expected error for invalid value, got nil")
 	}
 }
+
+func TestSortedMultiSelect_MapForm_Cleared(t *testing.T) {
+	f := &testMultiForm{}
+	f.Colors.SetSource(map[string]string{"r": "Red", "g": "Green"})
+	_ = f.Colors.Set([]string{"r"})
+
+	// The empty hidden input is all that is submitted when nothing is checked.
+	req := &http.Request{Form: url.Values{"Colors": {""}}}
+	if err := MapForm(req, f); err != nil {
+		t.Fatalf("MapForm failed: %v", err)
+	}
+	if got := f.Colors.Get(); len(got) != 0 {
+		t.Errorf("expected Colors to be cleared, got %v", got)
+	}
+
+	req = &http.Request{Form: url.Values{"Colors": {"", "g"}}}
+	if err := MapForm(req, f); err != nil {
+		t.Fatalf("MapForm failed: %v", err)
+	}
+	if got := f.Colors.Get(); len(got) != 1 || got[0] != "g" {
+		t.Errorf("expected Colors = [g], got %v", got)
+	}
+}
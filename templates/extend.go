@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"reflect"
+)
+
+// ExtendTheme registers a theme called name that inherits every template and class of
+// the registered theme base. A built-in base such as "bootstrap" is registered first
+// if it is not yet, so InitThemes does not have to be called. See Theme.Extend.
+func ExtendTheme(base, name string, fsys fs.FS, classes ThemeClasses) (*Theme, error) {
+	if _, ok := builtinThemes[base]; ok {
+		if err := registerBuiltinTheme(base); err != nil {
+			return nil, err
+		}
+	}
+	parent, ok := GetTheme(base)
+	if !ok {
+		return nil, fmt.Errorf("base theme %q is not registered", base)
+	}
+	theme, err := parent.Extend(name, fsys, classes)
+	if err != nil {
+		return nil, err
+	}
+
+	themeCache.Lock()
+	themeCache.themes[name] = theme
+	themeCache.Unlock()
+	return theme, nil
+}
+
+// Extend returns a new, unregistered theme called name that inherits every template
+// and class of t. Templates in fsys (.gohtml files, named after the file) replace the
+// inherited template with the same name or add new ones; fsys may be nil. Every
+// StyleOption set in classes replaces the inherited option, all others are kept.
+func (t *Theme) Extend(name string, fsys fs.FS, classes ThemeClasses) (*Theme, error) {
+	t.execMu.RLock()
+	defer t.execMu.RUnlock()
+	if t.Templates == nil {
+		return nil, fmt.Errorf("base theme %q has no templates loaded", t.Name)
+	}
+
+	theme := NewTheme(name, MergeClasses(t.Classes, classes), maps.Clone(t.AttrMap))
+
+	// The inherited theme helpers are bound to t; rebind them to the new theme so its
+	// classes are used.
+	tmpl, err := t.Templates.Clone()
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Funcs(theme.funcMap())
+	if fsys != nil {
+		if err := parseTemplatesFS(tmpl, fsys, "."); err != nil {
+			return nil, err
+		}
+	}
+	theme.Templates = tmpl
+	return theme, nil
+}
+
+// MergeClasses returns base with every StyleOption that is set in override replaced.
+func MergeClasses(base, override ThemeClasses) ThemeClasses {
+	merged := base
+	mv := reflect.ValueOf(&merged).Elem()
+	ov := reflect.ValueOf(override)
+	for i := 0; i < ov.NumField(); i++ {
+		if !ov.Field(i).IsZero() {
+			mv.Field(i).Set(ov.Field(i))
+		}
+	}
+	return merged
+}
@@ -0,0 +1,29 @@
+package templates
+
+import "testing"
+
+func TestExtendTheme_RegistersBuiltinBase(t *testing.T) {
+	themeCache.Lock()
+	saved, had := themeCache.themes["pico"]
+	delete(themeCache.themes, "pico")
+	themeCache.Unlock()
+	t.Cleanup(func() {
+		themeCache.Lock()
+		defer themeCache.Unlock()
+		delete(themeCache.themes, "pico-team")
+		if had {
+			themeCache.themes["pico"] = saved
+		}
+	})
+
+	theme, err := ExtendTheme("pico", "pico-team", nil, ThemeClasses{})
+	if err != nil {
+		t.Fatalf("ExtendTheme: %v", err)
+	}
+	if !theme.HasTemplate("form") {
+		t.Error("extended theme did not inherit the built-in templates")
+	}
+	if base, ok := GetTheme("pico"); !ok || !base.Loaded() {
+		t.Error("expected the built-in base theme to be registered")
+	}
+}
@@ -0,0 +1,113 @@
+package templates_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/donseba/go-form/v2/templates"
+	"github.com/donseba/go-form/v2/types"
+)
+
+func TestExtendTheme_InheritsAndOverrides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"wrapper.gohtml": {Data: []byte(`<div class="team-wrapper {{ themeClass "wrapper" }}">{{ .Label }}{{ .Control }}</div>`)},
+		"README.md":      {Data: []byte("ignored")},
+	}
+	theme, err := templates.ExtendTheme("bootstrap", "team", fsys, templates.ThemeClasses{
+		Wrapper: templates.StyleOption{Class: "team-row"},
+		Input:   templates.StyleOption{Class: "team-input"},
+	})
+	if err != nil {
+		t.Fatalf("ExtendTheme: %v", err)
+	}
+	if got, ok := templates.GetTheme("team"); !ok || got != theme {
+		t.Fatal("extended theme was not registered")
+	}
+
+	// Untouched classes are inherited field by field.
+	if theme.Classes.Select != templates.BootstrapTheme.Select || theme.Classes.Input.Class != "team-input" {
+		t.Errorf("unexpected merged classes: %+v", theme.Classes)
+	}
+
+	var buf bytes.Buffer
+	if err := theme.ExecuteTemplate(&buf, "wrapper", map[string]any{"Label": "L", "Control": "C"}); err != nil {
+		t.Fatalf("execute wrapper: %v", err)
+	}
+	if buf.String() != `<div class="team-wrapper team-row">LC</div>` {
+		t.Errorf("unexpected wrapper output: %s", buf.String())
+	}
+
+	// Inherited templates use the extended theme's classes.
+	buf.Reset()
+	data := map[string]any{"Type": "text", "Field": types.FormField{Id: "n", Name: "n"}, "Loc": dummyLoc{}}
+	if err := theme.ExecuteTemplate(&buf, "input", data); err != nil {
+		t.Fatalf("execute input: %v", err)
+	}
+	if !strings.Contains(buf.String(), `class="team-input`) {
+		t.Errorf("inherited input does not use the extended classes: %s", buf.String())
+	}
+
+	// The base theme is unaffected.
+	base, _ := templates.GetTheme("bootstrap")
+	buf.Reset()
+	if err := base.ExecuteTemplate(&buf, "input", data); err != nil {
+		t.Fatalf("execute base input: %v", err)
+	}
+	if strings.Contains(buf.String(), "team-input") {
+		t.Errorf("base theme picked up extended classes: %s", buf.String())
+	}
+}
+
+func TestExtendTheme_Errors(t *testing.T) {
+	if _, err := templates.ExtendTheme("no-such-theme", "x", nil, templates.ThemeClasses{}); err == nil {
+		t.Error("expected error for unknown base theme")
+	}
+	bad := fstest.MapFS{"form.gohtml": {Data: []byte(`{{ .Field.Target `)}}
+	if _, err := templates.ExtendTheme("bootstrap", "bad", bad, templates.ThemeClasses{}); err == nil {
+		t.Error("expected parse error")
+	}
+	if _, ok := templates.GetTheme("bad"); ok {
+		t.Error("theme with parse error should not be registered")
+	}
+}
+
+func TestRemovedRenderFuncsReportReplacement(t *testing.T) {
+	theme, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := theme.AddTemplate("group", `<div>{{ fields }}</div>`); err != nil {
+		t.Fatalf("a template using a removed func should still parse: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = theme.ExecuteTemplate(&buf, "group", map[string]any{"Fields": "F"})
+	if err == nil || !strings.Contains(err.Error(), "{{ .Fields }}") {
+		t.Errorf("expected an error naming .Fields, got %v", err)
+	}
+}
+
+func TestRenderTemplate_KeepsThemeExtendable(t *testing.T) {
+	theme, err := templates.NewBuiltinTheme("plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := theme.AddTemplate("greeting", `hello {{ . }}`); err != nil {
+		t.Fatalf("AddTemplate: %v", err)
+	}
+	if out, err := theme.RenderTemplate("greeting", "world"); err != nil || out != "hello world" {
+		t.Fatalf("RenderTemplate = %q, %v", out, err)
+	}
+
+	if _, err := theme.Extend("plain-child", nil, templates.ThemeClasses{}); err != nil {
+		t.Errorf("Extend after RenderTemplate: %v", err)
+	}
+	if err := theme.AddTemplate("greeting", `bye {{ . }}`); err != nil {
+		t.Fatalf("AddTemplate after RenderTemplate: %v", err)
+	}
+	if out, _ := theme.RenderTemplate("greeting", "world"); out != "bye world" {
+		t.Errorf("RenderTemplate after AddTemplate = %q", out)
+	}
+}
@@ -0,0 +1,147 @@
+package templates
+
+import (
+	"html/template"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// hotReload holds the state of a theme whose templates are re-read from disk.
+type hotReload struct {
+	mu       sync.Mutex
+	dir      string
+	interval time.Duration
+	base     *template.Template // the templates before the directory is parsed on top
+	files    map[string]fileStamp
+	checked  time.Time
+	err      error
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// EnableHotReload makes the theme re-read the .gohtml files in dir while rendering,
+// for development. Files in dir are parsed on top of the theme's current templates,
+// so dir may hold only the templates being worked on. Before a render, at most once
+// per interval, Reload compares the modification times of the files and re-parses
+// them when one was added, changed or removed. A parse error keeps the last good
+// templates; the form renderer shows the error in place of the form until the file
+// is fixed.
+//
+// Polling stats every file in dir, so do not enable it in production.
+func (t *Theme) EnableHotReload(dir string, interval time.Duration) error {
+	t.execMu.Lock()
+	base := t.Templates
+	if base == nil {
+		base = template.New("").Funcs(t.funcMap())
+	}
+	r := &hotReload{dir: dir, interval: interval, base: base}
+	t.reload = r
+	t.execMu.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return t.reloadLocked(r, time.Now())
+}
+
+// Reload re-parses the templates of a theme with hot reload enabled when files
+// changed since the last check. It returns the current parse error, which is
+// reported until the file is fixed. Without hot reload it does nothing. A variant
+// reloads its base theme and follows its changes.
+func (t *Theme) Reload() error {
+	if t.parent != nil {
+		if err := t.parent.Reload(); err != nil {
+			return err
+		}
+		return t.syncParent()
+	}
+
+	t.execMu.RLock()
+	r := t.reload
+	t.execMu.RUnlock()
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if now.Sub(r.checked) < r.interval {
+		return r.err
+	}
+	return t.reloadLocked(r, now)
+}
+
+func (t *Theme) reloadLocked(r *hotReload, now time.Time) error {
+	r.checked = now
+
+	files, err := stampFiles(r.dir)
+	if err != nil {
+		r.err = err
+		return err
+	}
+	if r.files != nil && maps.Equal(files, r.files) {
+		return r.err
+	}
+	r.files = files
+
+	tmpl, err := r.base.Clone()
+	if err == nil {
+		err = parseTemplatesFS(tmpl, os.DirFS(r.dir), ".")
+	}
+	r.err = err
+	if err != nil {
+		return err
+	}
+	t.setTemplates(tmpl)
+	return nil
+}
+
+// stampFiles returns the modification time and size of every .gohtml file below dir.
+func stampFiles(dir string) (map[string]fileStamp, error) {
+	files := make(map[string]fileStamp)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".gohtml" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return files, err
+}
+
+// addReloadTemplate adds a template to the base set of hot reload, so it survives
+// the next re-parse of the directory.
+func (t *Theme) addReloadTemplate(name, text string) error {
+	t.execMu.RLock()
+	r := t.reload
+	t.execMu.RUnlock()
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	base, err := r.base.Clone()
+	if err != nil {
+		return err
+	}
+	if _, err := base.New(name).Parse(text); err != nil {
+		return err
+	}
+	r.base = base
+	return nil
+}
@@ -0,0 +1,95 @@
+package templates_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/donseba/go-form/v2/templates"
+)
+
+func TestThemeHotReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hello.gohtml")
+	write := func(text string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func(theme *templates.Theme) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := theme.ExecuteTemplate(&buf, "hello", nil); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		return buf.String()
+	}
+
+	now := time.Now()
+	write(`one`, now.Add(-time.Hour))
+
+	theme := templates.NewTheme("dev", templates.ThemeClasses{Input: templates.StyleOption{Class: "dev-input"}}, nil)
+	if err := theme.EnableHotReload(dir, 0); err != nil {
+		t.Fatalf("EnableHotReload: %v", err)
+	}
+	if got := render(theme); got != "one" {
+		t.Fatalf("got %q, want one", got)
+	}
+
+	write(`two {{ themeClass "input" }}`, now)
+	if err := theme.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := render(theme); got != "two dev-input" {
+		t.Fatalf("got %q after change", got)
+	}
+
+	// A parse error keeps the last good templates and is reported until fixed.
+	write(`{{ if }}`, now.Add(time.Minute))
+	if err := theme.Reload(); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if err := theme.Reload(); err == nil {
+		t.Fatal("expected parse error to be reported until fixed")
+	}
+	if got := render(theme); got != "two dev-input" {
+		t.Fatalf("got %q, want last good template", got)
+	}
+
+	// Templates added at runtime survive a reload.
+	if err := theme.AddTemplate("widget", `w`); err != nil {
+		t.Fatalf("AddTemplate: %v", err)
+	}
+	write(`three`, now.Add(2*time.Minute))
+	if err := theme.Reload(); err != nil {
+		t.Fatalf("Reload after fix: %v", err)
+	}
+	if got := render(theme); got != "three" || !theme.HasTemplate("widget") {
+		t.Fatalf("got %q, widget kept: %v", got, theme.HasTemplate("widget"))
+	}
+}
+
+func TestThemeHotReload_Interval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hello.gohtml")
+	if err := os.WriteFile(path, []byte(`one`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	theme := templates.NewTheme("dev", templates.ThemeClasses{}, nil)
+	if err := theme.EnableHotReload(dir, time.Hour); err != nil {
+		t.Fatalf("EnableHotReload: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{{ if }}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := theme.Reload(); err != nil {
+		t.Fatalf("files should not be checked before the interval passed: %v", err)
+	}
+}
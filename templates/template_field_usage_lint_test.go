@@ -54,6 +54,11 @@ func TestGoHTMLTemplates_FieldAccessIsValid(t *testing.T) {
 		"Class":        {},
 		"Data":         {},
 		"ValueMap":     {}, // allow ValueMap for multicheckbox
+		"Accept":       {},
+		"Multiple":     {},
+		"Enctype":      {},
+		"Prototype":    {},
+		"Widget":       {},
 	}
 
 	allowedRoot := map[string]struct{}{
@@ -65,6 +70,18 @@ func TestGoHTMLTemplates_FieldAccessIsValid(t *testing.T) {
 		"GroupBefore": {},
 		"GroupAfter":  {},
 		"Input":       {},
+
+		// render content passed by the form renderer
+		"Label":        {},
+		"Control":      {},
+		"Fields":       {},
+		"Errors":       {},
+		"Rows":         {},
+		"Prototype":    {},
+		"HideSubmit":   {},
+		"ReadOnly":     {},
+		"ErrorSummary": {},
+		"Summary":      {},
 	}
 
 	// Helpers provided by theme loader + renderer.
@@ -76,20 +93,25 @@ func TestGoHTMLTemplates_FieldAccessIsValid(t *testing.T) {
 		"form_print":           func(...any) string { return "" },
 		"form_data_attributes": func(...any) string { return "" },
 		"form_attributes":      func(...any) string { return "" },
-		// callable blocks in some templates
-		"errors": func() []string { return nil },
-		"field":  func() template.HTML { return "" },
-		"fields": func() template.HTML { return "" },
-		"label":  func() template.HTML { return "" },
 	}
 
 	dummy := struct {
-		Field       types.FormField
-		Loc         dummyLoc
-		Type        string
-		GroupBefore template.HTML
-		GroupAfter  template.HTML
-		Input       template.HTML
+		Field        types.FormField
+		Loc          dummyLoc
+		Type         string
+		GroupBefore  template.HTML
+		GroupAfter   template.HTML
+		Input        template.HTML
+		Label        template.HTML
+		Control      template.HTML
+		Fields       template.HTML
+		Errors       []string
+		Rows         []template.HTML
+		Prototype    template.HTML
+		HideSubmit   bool
+		ReadOnly     bool
+		ErrorSummary template.HTML
+		Summary      []types.ErrorSummaryItem
 	}{
 		Field:       types.FormField{},
 		Loc:         dummyLoc{},
@@ -99,34 +121,43 @@ func TestGoHTMLTemplates_FieldAccessIsValid(t *testing.T) {
 		Input:       "",
 	}
 
-	// Walk embedded template FS and lint each .gohtml.
-	var files []string
-	err := fs.WalkDir(TemplateFS, "gohtml", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			return nil
-		}
-		if filepath.Ext(path) != ".gohtml" {
+	// Walk the shared templates and the theme overlays and lint each .gohtml.
+	type templateFile struct {
+		fsys fs.FS
+		path string
+	}
+	var files []templateFile
+	for _, root := range []struct {
+		fsys fs.FS
+		dir  string
+	}{{TemplateFS, "gohtml"}, {overlayFS, "overlays"}} {
+		err := fs.WalkDir(root.fsys, root.dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if filepath.Ext(path) != ".gohtml" {
+				return nil
+			}
+			files = append(files, templateFile{root.fsys, path})
 			return nil
+		})
+		if err != nil {
+			t.Fatalf("walk embedded templates: %v", err)
 		}
-		files = append(files, path)
-		return nil
-	})
-	if err != nil {
-		t.Fatalf("walk embedded templates: %v", err)
 	}
 
 	if len(files) == 0 {
 		t.Fatalf("no gohtml templates found in embedded FS")
 	}
 
-	for _, path := range files {
-		path := path
-		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	for _, file := range files {
+		path := file.path
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "overlays/"), filepath.Ext(path))
 		t.Run(name, func(t *testing.T) {
-			b, err := fs.ReadFile(TemplateFS, path)
+			b, err := fs.ReadFile(file.fsys, path)
 			if err != nil {
 				t.Fatalf("read %s: %v", path, err)
 			}
@@ -3,6 +3,7 @@ package templates
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -22,11 +23,12 @@ type StyleOption struct {
 // ThemeClasses represents all CSS classes used in a theme
 type ThemeClasses struct {
 	// Common UI elements
-	Wrapper     StyleOption
-	Label       StyleOption
-	Required    StyleOption
-	Error       StyleOption
-	Description StyleOption
+	Wrapper      StyleOption
+	Label        StyleOption
+	Required     StyleOption
+	Error        StyleOption
+	Description  StyleOption
+	ErrorSummary StyleOption // the list of all errors at the top of a form
 
 	// Input elements
 	Input           StyleOption
@@ -60,6 +62,18 @@ type ThemeClasses struct {
 	// Input groups
 	InputGroup     StyleOption
 	InputGroupText StyleOption
+
+	// Repeaters
+	Repeater       StyleOption
+	RepeaterRow    StyleOption
+	RepeaterAdd    StyleOption
+	RepeaterRemove StyleOption
+}
+
+// Printer is implemented by the Loc value the form renderer passes to templates.
+// form_print uses it so translations follow the rendering Form's settings.
+type Printer interface {
+	Print(key string, args ...any) string
 }
 
 // Theme represents a form theme with CSS classes and attributes
@@ -68,6 +82,21 @@ type Theme struct {
 	Classes   ThemeClasses
 	AttrMap   map[string]string
 	Templates *template.Template
+
+	// exec is the clone of Templates used for rendering. html/template cannot clone a
+	// set once it has been executed, so Templates itself stays unexecuted and can
+	// still be cloned, e.g. to derive another theme from it.
+	execMu sync.RWMutex
+	exec   *template.Template
+
+	// reload is set by EnableHotReload.
+	reload *hotReload
+
+	// variants caches the variants derived from this theme. A variant keeps its
+	// parent and the parent templates it was cloned from, see Variant.
+	variants        map[string]*Theme
+	parent          *Theme
+	parentTemplates *template.Template
 }
 
 // themeCache stores precompiled templates for themes
@@ -78,17 +107,22 @@ var themeCache = struct {
 	themes: make(map[string]*Theme),
 }
 
-// RegisterTheme registers a new theme with the given name and classes
-func RegisterTheme(name string, classes ThemeClasses, attrMap map[string]string) *Theme {
-	themeCache.Lock()
-	defer themeCache.Unlock()
-
-	theme := &Theme{
+// NewTheme returns a theme with the given name and classes without registering it.
+// Load its templates with LoadTemplatesFS and hand it to a form with form.WithTheme.
+func NewTheme(name string, classes ThemeClasses, attrMap map[string]string) *Theme {
+	return &Theme{
 		Name:    name,
 		Classes: classes,
 		AttrMap: attrMap,
 	}
+}
 
+// RegisterTheme registers a new theme with the given name and classes
+func RegisterTheme(name string, classes ThemeClasses, attrMap map[string]string) *Theme {
+	theme := NewTheme(name, classes, attrMap)
+
+	themeCache.Lock()
+	defer themeCache.Unlock()
 	themeCache.themes[name] = theme
 	return theme
 }
@@ -102,75 +136,110 @@ func GetTheme(name string) (*Theme, bool) {
 	return theme, found
 }
 
-// LoadTemplates loads all .gohtml templates from the given directory and associates them with the theme
-func (t *Theme) LoadTemplates(templateDir string) error {
-	tmpl := template.New("")
-
-	// Register helper functions
-	tmpl.Funcs(template.FuncMap{
-		"themeClass": func(key string) string {
-			option := t.getStyleOptionForKey(key)
-			return option.Class
-		},
-		"themeStyle": func(key string) template.CSS {
-			option := t.getStyleOptionForKey(key)
-			return template.CSS(option.Style)
-		},
-		"themeAttr": func(key string) string {
-			if attr, ok := t.AttrMap[key]; ok {
-				return attr
-			}
-			return ""
-		},
-	})
-
-	// Walk the template directory
-	err := filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+// ExecuteTemplate renders the named template to w. The template set is cloned from
+// Templates once, on first use, and shared by all renders after that.
+func (t *Theme) ExecuteTemplate(w io.Writer, name string, data any) error {
+	tmpl, err := t.execTemplates()
+	if err != nil {
+		return err
+	}
+	return tmpl.ExecuteTemplate(w, name, data)
+}
 
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
+func (t *Theme) execTemplates() (*template.Template, error) {
+	t.execMu.RLock()
+	tmpl := t.exec
+	t.execMu.RUnlock()
+	if tmpl != nil {
+		return tmpl, nil
+	}
 
-		// Only process .gohtml files
-		if filepath.Ext(path) != ".gohtml" {
-			return nil
-		}
+	t.execMu.Lock()
+	defer t.execMu.Unlock()
+	if t.exec != nil {
+		return t.exec, nil
+	}
+	if t.Templates == nil {
+		return nil, fmt.Errorf("theme %q has no templates loaded", t.Name)
+	}
+	tmpl, err := t.Templates.Clone()
+	if err != nil {
+		return nil, err
+	}
+	t.exec = tmpl
+	return tmpl, nil
+}
 
-		// Read the template file
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return err
-		}
+// Loaded reports whether the theme has templates. It is safe to call while the
+// templates are replaced, e.g. by a hot reload.
+func (t *Theme) Loaded() bool {
+	t.execMu.RLock()
+	defer t.execMu.RUnlock()
+	return t.Templates != nil
+}
 
-		// Get template name (without extension)
-		name := filepath.Base(path)
-		name = name[:len(name)-len(filepath.Ext(name))]
+// HasTemplate reports whether the theme defines a template called name.
+func (t *Theme) HasTemplate(name string) bool {
+	t.execMu.RLock()
+	defer t.execMu.RUnlock()
+	return t.Templates != nil && t.Templates.Lookup(name) != nil
+}
 
-		// Parse the template
-		_, err = tmpl.New(name).Parse(string(content))
+// AddTemplate parses text as a template called name into the theme, replacing a
+// template with the same name. The theme's functions are available to it. Use it to
+// add widgets that fields select with the `template` struct tag.
+func (t *Theme) AddTemplate(name, text string) error {
+	if err := t.addReloadTemplate(name, text); err != nil {
 		return err
-	})
+	}
 
+	t.execMu.Lock()
+	defer t.execMu.Unlock()
+	if t.Templates == nil {
+		return fmt.Errorf("theme %q has no templates loaded", t.Name)
+	}
+	// Parse into a copy so a failed parse leaves the theme untouched.
+	tmpl, err := t.Templates.Clone()
 	if err != nil {
 		return err
 	}
-
+	if _, err := tmpl.New(name).Parse(text); err != nil {
+		return err
+	}
 	t.Templates = tmpl
+	t.exec = nil
 	return nil
 }
 
+// setTemplates replaces the theme's templates and drops the executable clone.
+func (t *Theme) setTemplates(tmpl *template.Template) {
+	t.execMu.Lock()
+	defer t.execMu.Unlock()
+	t.Templates = tmpl
+	t.exec = nil
+}
+
+// LoadTemplates loads all .gohtml templates from the given directory and associates them with the theme
+func (t *Theme) LoadTemplates(templateDir string) error {
+	return t.LoadTemplatesFS(os.DirFS(templateDir), ".")
+}
+
 // LoadTemplatesFS loads all .gohtml templates from the given embedded filesystem
 func (t *Theme) LoadTemplatesFS(fsys fs.FS, rootDir string) error {
-	// Always start from a fresh template set. This lets InitThemes be called
-	// multiple times in tests and ensures template content is not stale.
-	tmpl := template.New("")
+	// Always start from a fresh template set so template content is not stale.
+	tmpl := template.New("").Funcs(t.funcMap())
+	if err := parseTemplatesFS(tmpl, fsys, rootDir); err != nil {
+		return err
+	}
 
-	// Register helper functions
-	tmpl.Funcs(template.FuncMap{
+	t.setTemplates(tmpl)
+	return nil
+}
+
+// funcMap returns the helper functions available to the theme's templates. The
+// theme helpers are bound to t.
+func (t *Theme) funcMap() template.FuncMap {
+	return template.FuncMap{
 		// default returns the fallback value when the piped value is the zero value.
 		// Usage: {{ .Something | default "fallback" }}
 		"default": func(v any, fallback any) any {
@@ -237,19 +306,35 @@ func (t *Theme) LoadTemplatesFS(fsys fs.FS, rootDir string) error {
 			}
 			return ""
 		},
-		// Placeholders for templates that expect callable blocks/functions.
-		// Callers can override these via template clone + Funcs() before executing.
-		"fields":               func() template.HTML { return "" },
-		"field":                func() template.HTML { return "" },
-		"label":                func() template.HTML { return "" },
-		"errors":               func() []string { return nil },
+		// form_print translates through the Printer passed as Loc, see Printer.
 		"form_print":           funcPrint,
 		"form_attributes":      funcAttributes,
 		"form_data_attributes": funcDataAttributes,
-	})
 
+		// Deprecated: the per-render funcs of earlier versions. Their content is
+		// template data now; they fail with the replacement to use.
+		"fields":    removedFunc("fields", ".Fields"),
+		"field":     removedFunc("field", ".Control"),
+		"label":     removedFunc("label", ".Label"),
+		"errors":    removedFunc("errors", ".Errors"),
+		"rows":      removedFunc("rows", ".Rows"),
+		"prototype": removedFunc("prototype", ".Prototype"),
+	}
+}
+
+// removedFunc returns a template func that fails, so a custom template written for an
+// earlier version still parses and reports which data field replaces name.
+func removedFunc(name, replacement string) func() (any, error) {
+	return func() (any, error) {
+		return nil, fmt.Errorf("template func %q was removed, use {{ %s }} instead", name, replacement)
+	}
+}
+
+// parseTemplatesFS parses every .gohtml file below rootDir into tmpl, named after the
+// file without its extension. A file replaces a template with the same name.
+func parseTemplatesFS(tmpl *template.Template, fsys fs.FS, rootDir string) error {
 	// Walk the embedded filesystem
-	err := fs.WalkDir(fsys, rootDir, func(path string, d fs.DirEntry, err error) error {
+	return fs.WalkDir(fsys, rootDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -278,13 +363,6 @@ func (t *Theme) LoadTemplatesFS(fsys fs.FS, rootDir string) error {
 		_, err = tmpl.New(name).Parse(string(content))
 		return err
 	})
-
-	if err != nil {
-		return err
-	}
-
-	t.Templates = tmpl
-	return nil
 }
 
 // getStyleOptionForKey returns the StyleOption for a given key
@@ -313,6 +391,8 @@ func (t *Theme) getStyleOptionForKey(key string) StyleOption {
 		return t.Classes.Error
 	case "description":
 		return t.Classes.Description
+	case "errorSummary":
+		return t.Classes.ErrorSummary
 	case "input":
 		return t.Classes.Input
 	case "select":
@@ -367,6 +447,14 @@ func (t *Theme) getStyleOptionForKey(key string) StyleOption {
 		return t.Classes.InputGroupText
 	case "multicheckbox":
 		return t.Classes.Multicheckbox
+	case "repeater":
+		return t.Classes.Repeater
+	case "repeaterRow":
+		return t.Classes.RepeaterRow
+	case "repeaterAdd":
+		return t.Classes.RepeaterAdd
+	case "repeaterRemove":
+		return t.Classes.RepeaterRemove
 	default:
 		return StyleOption{}
 	}
@@ -375,7 +463,7 @@ func (t *Theme) getStyleOptionForKey(key string) StyleOption {
 // RenderTemplate renders a template with the given name and data
 func (t *Theme) RenderTemplate(name string, data interface{}) (template.HTML, error) {
 	var buf strings.Builder
-	err := t.Templates.ExecuteTemplate(&buf, name, data)
+	err := t.ExecuteTemplate(&buf, name, data)
 	if err != nil {
 		return "", err
 	}
@@ -385,7 +473,9 @@ func (t *Theme) RenderTemplate(name string, data interface{}) (template.HTML, er
 // Define existing function references
 var (
 	funcPrint = func(loc types.Localizer, key string, args ...any) string {
-		_ = loc
+		if p, ok := loc.(Printer); ok {
+			return p.Print(key, args...)
+		}
 		if len(args) > 0 {
 			return fmt.Sprintf(key, args...)
 		}
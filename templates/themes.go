@@ -2,19 +2,30 @@ package templates
 
 import (
 	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
 )
 
 //go:embed gohtml/*.gohtml
 var TemplateFS embed.FS
 
+// overlayFS holds the templates of themes whose markup differs from the shared
+// templates in gohtml, one directory per theme.
+//
+//go:embed overlays
+var overlayFS embed.FS
+
 // BootstrapTheme defines Bootstrap v5 classes for form elements
 var BootstrapTheme = ThemeClasses{
 	// Common elements
-	Wrapper:     StyleOption{Class: "mb-2"},
-	Label:       StyleOption{Class: "form-label small mb-1"},
-	Required:    StyleOption{Class: "text-danger"},
-	Error:       StyleOption{Class: "invalid-feedback d-block small"},
-	Description: StyleOption{Class: "form-text small"},
+	Wrapper:      StyleOption{Class: "mb-2"},
+	Label:        StyleOption{Class: "form-label small mb-1"},
+	Required:     StyleOption{Class: "text-danger"},
+	Error:        StyleOption{Class: "invalid-feedback d-block small"},
+	Description:  StyleOption{Class: "form-text small"},
+	ErrorSummary: StyleOption{Class: "alert alert-danger small"},
 
 	// Input types
 	Input:           StyleOption{Class: "form-control"},
@@ -48,16 +59,23 @@ var BootstrapTheme = ThemeClasses{
 	// Input groups
 	InputGroup:     StyleOption{Class: "input-group"},
 	InputGroupText: StyleOption{Class: "input-group-text"},
+
+	// Repeaters
+	Repeater:       StyleOption{Class: "mb-2"},
+	RepeaterRow:    StyleOption{Class: "border rounded p-2 mb-2"},
+	RepeaterAdd:    StyleOption{Class: "btn btn-outline-primary btn-sm"},
+	RepeaterRemove: StyleOption{Class: "btn btn-outline-danger btn-sm"},
 }
 
 // TailwindTheme defines Tailwind CSS v3 classes for form elements
 var TailwindTheme = ThemeClasses{
 	// Common elements
-	Wrapper:     StyleOption{Class: "mb-2"},
-	Label:       StyleOption{Class: "block text-sm font-medium leading-6 text-gray-900"},
-	Required:    StyleOption{Class: "text-red-600"},
-	Error:       StyleOption{Class: "mt-1 text-sm text-red-600"},
-	Description: StyleOption{Class: "mt-1 text-sm text-gray-500"},
+	Wrapper:      StyleOption{Class: "mb-2"},
+	Label:        StyleOption{Class: "block text-sm font-medium leading-6 text-gray-900"},
+	Required:     StyleOption{Class: "text-red-600"},
+	Error:        StyleOption{Class: "mt-1 text-sm text-red-600"},
+	Description:  StyleOption{Class: "mt-1 text-sm text-gray-500"},
+	ErrorSummary: StyleOption{Class: "mb-4 rounded-md border border-red-200 bg-red-50 p-4 text-sm text-red-700"},
 
 	// Input types
 	Input:           StyleOption{Class: "border border-gray-200 block w-full rounded-md px-3 py-1.5 text-gray-900 shadow-sm ring-1 ring-inset ring-gray-300 placeholder:text-gray-400 focus:ring-2 focus:ring-inset focus:ring-indigo-600 sm:text-sm sm:leading-6"},
@@ -91,6 +109,12 @@ var TailwindTheme = ThemeClasses{
 	// Input groups
 	InputGroup:     StyleOption{Class: "flex rounded-md shadow-sm"},
 	InputGroupText: StyleOption{Class: "inline-flex items-center rounded-l-md border border-r-0 border-gray-300 bg-gray-50 px-3 text-gray-500 text-sm"},
+
+	// Repeaters
+	Repeater:       StyleOption{Class: "mb-2"},
+	RepeaterRow:    StyleOption{Class: "mb-2 rounded-md border border-gray-200 p-3"},
+	RepeaterAdd:    StyleOption{Class: "rounded-md bg-white px-3 py-1.5 text-sm font-semibold text-indigo-600 shadow-sm ring-1 ring-inset ring-indigo-200 hover:bg-indigo-50 disabled:opacity-50 disabled:cursor-not-allowed"},
+	RepeaterRemove: StyleOption{Class: "rounded-md bg-white px-2 py-1 text-sm text-red-600 ring-1 ring-inset ring-red-200 hover:bg-red-50 disabled:opacity-50 disabled:cursor-not-allowed"},
 }
 
 // TailwindV4Theme defines Tailwind CSS v4 classes for form elements.
@@ -99,11 +123,12 @@ var TailwindTheme = ThemeClasses{
 // existing TailwindV4 template set (focus-visible patterns + optional dark mode utilities).
 var TailwindV4Theme = ThemeClasses{
 	// Common elements
-	Wrapper:     StyleOption{Class: "mb-2"},
-	Label:       StyleOption{Class: "block text-sm font-medium leading-6 text-gray-900"},
-	Required:    StyleOption{Class: "text-red-600 dark:text-red-400"},
-	Error:       StyleOption{Class: "mt-1 text-sm text-red-600 dark:text-red-400"},
-	Description: StyleOption{Class: "mt-1 text-sm text-gray-500 dark:text-gray-300"},
+	Wrapper:      StyleOption{Class: "mb-2"},
+	Label:        StyleOption{Class: "block text-sm font-medium leading-6 text-gray-900"},
+	Required:     StyleOption{Class: "text-red-600 dark:text-red-400"},
+	Error:        StyleOption{Class: "mt-1 text-sm text-red-600 dark:text-red-400"},
+	Description:  StyleOption{Class: "mt-1 text-sm text-gray-500 dark:text-gray-300"},
+	ErrorSummary: StyleOption{Class: "mb-4 rounded-md border border-red-200 bg-red-50 p-4 text-sm text-red-700 dark:border-red-900 dark:bg-red-950 dark:text-red-300"},
 
 	// Input types
 	Input:           StyleOption{Class: "block w-full rounded-md border border-gray-300 bg-white px-3 py-2 text-sm text-gray-900 shadow-sm placeholder:text-gray-400 focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-indigo-600 focus-visible:ring-offset-2 focus-visible:ring-offset-white disabled:cursor-not-allowed disabled:opacity-50 dark:border-gray-700 dark:bg-gray-800 dark:text-gray-100 dark:placeholder:text-gray-400 dark:focus-visible:ring-indigo-500 dark:focus-visible:ring-offset-gray-900"},
@@ -137,15 +162,22 @@ var TailwindV4Theme = ThemeClasses{
 	// Input groups
 	InputGroup:     StyleOption{Class: "flex w-full rounded-md shadow-sm"},
 	InputGroupText: StyleOption{Class: "inline-flex items-center rounded-l-md border border-r-0 border-gray-300 bg-gray-50 px-3 text-sm text-gray-500 dark:border-gray-700 dark:bg-gray-700 dark:text-gray-200"},
+
+	// Repeaters
+	Repeater:       StyleOption{Class: "mb-2"},
+	RepeaterRow:    StyleOption{Class: "mb-2 rounded-md border border-gray-200 p-3 dark:border-gray-700"},
+	RepeaterAdd:    StyleOption{Class: "rounded-md bg-white px-3 py-1.5 text-sm font-semibold text-indigo-600 shadow-sm ring-1 ring-inset ring-indigo-200 hover:bg-indigo-50 focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-indigo-600 disabled:cursor-not-allowed disabled:opacity-50 dark:bg-gray-800 dark:text-indigo-400 dark:ring-gray-700 dark:hover:bg-gray-700"},
+	RepeaterRemove: StyleOption{Class: "rounded-md bg-white px-2 py-1 text-sm text-red-600 ring-1 ring-inset ring-red-200 hover:bg-red-50 focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-red-600 disabled:cursor-not-allowed disabled:opacity-50 dark:bg-gray-800 dark:text-red-400 dark:ring-gray-700 dark:hover:bg-gray-700"},
 }
 
 // PlainTheme defines simple, unstyled HTML with inline styles
 var PlainTheme = ThemeClasses{
 	// Common elements
-	Wrapper:     StyleOption{Style: "margin-bottom: 0.5rem;"},
-	Label:       StyleOption{Style: "display: block; margin-bottom: 0.25rem; font-size: 0.875rem; font-weight: 500; color: #212529;"},
-	Error:       StyleOption{Style: "display: block; width: 100%; margin-top: 0.25rem; font-size: 0.75rem; color: #dc3545;"},
-	Description: StyleOption{Style: "margin-top: 0.25rem; font-size: 0.75rem; color: #6c757d;"},
+	Wrapper:      StyleOption{Style: "margin-bottom: 0.5rem;"},
+	Label:        StyleOption{Style: "display: block; margin-bottom: 0.25rem; font-size: 0.875rem; font-weight: 500; color: #212529;"},
+	Error:        StyleOption{Style: "display: block; width: 100%; margin-top: 0.25rem; font-size: 0.75rem; color: #dc3545;"},
+	Description:  StyleOption{Style: "margin-top: 0.25rem; font-size: 0.75rem; color: #6c757d;"},
+	ErrorSummary: StyleOption{Style: "margin-bottom: 1rem; padding: 0.75rem 1rem; font-size: 0.875rem; color: #842029; background-color: #f8d7da; border: 1px solid #f5c2c7; border-radius: 0.25rem;"},
 
 	// Input types
 	Input:           StyleOption{Style: "width: 100%; padding: 0.375rem 0.75rem; font-size: 0.875rem; line-height: 1.5; color: #212529; background-color: #fff; border: 1px solid #ced4da; border-radius: 0.25rem; transition: border-color 0.15s ease-in-out, box-shadow 0.15s ease-in-out;"},
@@ -173,36 +205,236 @@ var PlainTheme = ThemeClasses{
 	// Input groups
 	InputGroup:     StyleOption{Style: "display: flex; align-items: stretch; width: 100%;"},
 	InputGroupText: StyleOption{Style: "display: inline-flex; align-items: center; padding: 0 0.75rem; background: #f8f9fa; border: 1px solid #ced4da; border-right: 0; border-radius: 0.25rem 0 0 0.25rem; color: #6c757d; font-size: 0.875rem;"},
+
+	// Repeaters
+	Repeater:       StyleOption{Style: "margin-bottom: 0.5rem;"},
+	RepeaterRow:    StyleOption{Style: "margin-bottom: 0.5rem; padding: 0.5rem; border: 1px solid #dee2e6; border-radius: 0.25rem;"},
+	RepeaterAdd:    StyleOption{Style: "display: inline-block; padding: 0.25rem 0.5rem; font-size: 0.875rem; line-height: 1.5; border: 1px solid #0d6efd; border-radius: 0.25rem; color: #0d6efd; background-color: #fff; cursor: pointer;"},
+	RepeaterRemove: StyleOption{Style: "display: inline-block; padding: 0.25rem 0.5rem; font-size: 0.875rem; line-height: 1.5; border: 1px solid #dc3545; border-radius: 0.25rem; color: #dc3545; background-color: #fff; cursor: pointer;"},
 }
 
-// Initialize themes
-func InitThemes() error {
-	// Register themes with appropriate inline style setting
-	RegisterTheme("bootstrap", BootstrapTheme, nil)   // false = uses CSS classes
-	RegisterTheme("tailwind", TailwindTheme, nil)     // false = uses CSS classes
-	RegisterTheme("tailwindv4", TailwindV4Theme, nil) // false = uses CSS classes
-	RegisterTheme("plain", PlainTheme, nil)           // true = uses inline styles
-
-	// Load templates using the embedded filesystem
-	if theme, ok := GetTheme("bootstrap"); ok {
-		if err := theme.LoadTemplatesFS(TemplateFS, "gohtml"); err != nil {
-			return err
-		}
+// BulmaTheme defines Bulma v1 classes for form elements. Bulma nests every control in
+// a field/control pair; the templates in overlays/bulma provide that structure.
+var BulmaTheme = ThemeClasses{
+	// Common elements
+	Wrapper:      StyleOption{Class: "field"},
+	Label:        StyleOption{Class: "label"},
+	Required:     StyleOption{Class: "has-text-danger"},
+	Error:        StyleOption{Class: "help is-danger"},
+	Description:  StyleOption{Class: "help"},
+	ErrorSummary: StyleOption{Class: "notification is-danger is-light"},
+
+	// Input types
+	Input:           StyleOption{Class: "input"},
+	Select:          StyleOption{Class: "select is-fullwidth"},
+	Textarea:        StyleOption{Class: "textarea"},
+	TextareaWrapper: StyleOption{},
+	Radio:           StyleOption{},
+	RadioWrapper:    StyleOption{},
+	RadioLabel:      StyleOption{Class: "radio"},
+	RadioGroup:      StyleOption{Class: "radios"},
+	Checkbox:        StyleOption{},
+	CheckboxWrapper: StyleOption{},
+	CheckboxLabel:   StyleOption{Class: "checkbox"},
+	Range:           StyleOption{Style: "width: 100%;"},
+	RangeWrapper:    StyleOption{},
+	RangeValue:      StyleOption{Class: "help"},
+	Color:           StyleOption{Class: "input", Style: "width: 4rem; padding: 0.25rem;"},
+	Button:          StyleOption{Class: "button is-primary"},
+	Cancel:          StyleOption{Class: "button is-light"},
+	File:            StyleOption{Class: "file-input"},
+	Multicheckbox:   StyleOption{Class: "checkboxes"},
+
+	// Form container
+	Form:        StyleOption{Class: "box"},
+	FormGroup:   StyleOption{Class: "card mb-4"},
+	FormHeader:  StyleOption{Class: "card-header"},
+	FormLegend:  StyleOption{Class: "card-header-title"},
+	FormBody:    StyleOption{Class: "card-content"},
+	FormButtons: StyleOption{Class: "buttons is-right mt-4"},
+
+	// Input groups
+	InputGroup:     StyleOption{Class: "field has-addons"},
+	InputGroupText: StyleOption{Class: "button is-static"},
+
+	// Repeaters
+	Repeater:       StyleOption{Class: "mb-4"},
+	RepeaterRow:    StyleOption{Class: "box"},
+	RepeaterAdd:    StyleOption{Class: "button is-small is-link is-light"},
+	RepeaterRemove: StyleOption{Class: "button is-small is-danger is-light"},
+}
+
+// PicoTheme defines Pico CSS v2 classes for form elements. Pico styles the plain HTML
+// elements, so most options are empty; the templates in overlays/pico use the
+// elements Pico expects for groups and helper text.
+var PicoTheme = ThemeClasses{
+	// Common elements
+	Wrapper:      StyleOption{},
+	Label:        StyleOption{},
+	Required:     StyleOption{Style: "color: var(--pico-del-color);"},
+	Error:        StyleOption{Style: "color: var(--pico-del-color);"},
+	Description:  StyleOption{},
+	ErrorSummary: StyleOption{Style: "margin-bottom: var(--pico-spacing); padding-left: var(--pico-spacing); border-left: 0.25rem solid var(--pico-del-color); color: var(--pico-del-color);"},
+
+	// Input types
+	Input:           StyleOption{},
+	Select:          StyleOption{},
+	Textarea:        StyleOption{},
+	TextareaWrapper: StyleOption{},
+	Radio:           StyleOption{},
+	RadioWrapper:    StyleOption{Style: "display: inline-block; margin-right: var(--pico-spacing);"},
+	RadioLabel:      StyleOption{},
+	RadioGroup:      StyleOption{},
+	Checkbox:        StyleOption{},
+	CheckboxWrapper: StyleOption{},
+	CheckboxLabel:   StyleOption{},
+	Range:           StyleOption{},
+	RangeWrapper:    StyleOption{},
+	RangeValue:      StyleOption{Style: "color: var(--pico-muted-color);"},
+	Color:           StyleOption{},
+	Button:          StyleOption{},
+	Cancel:          StyleOption{Class: "secondary", Style: "margin-right: var(--pico-spacing);"},
+	File:            StyleOption{},
+	Multicheckbox:   StyleOption{},
+
+	// Form container
+	Form:        StyleOption{},
+	FormGroup:   StyleOption{},
+	FormHeader:  StyleOption{},
+	FormLegend:  StyleOption{Style: "margin: 0;"},
+	FormBody:    StyleOption{},
+	FormButtons: StyleOption{Class: "grid"},
+
+	// Input groups
+	InputGroup:     StyleOption{},
+	InputGroupText: StyleOption{Class: "secondary outline"},
+
+	// Repeaters
+	Repeater:       StyleOption{},
+	RepeaterRow:    StyleOption{Style: "margin-bottom: var(--pico-spacing); padding: var(--pico-spacing); border: var(--pico-border-width) solid var(--pico-muted-border-color); border-radius: var(--pico-border-radius);"},
+	RepeaterAdd:    StyleOption{Class: "secondary outline"},
+	RepeaterRemove: StyleOption{Class: "secondary outline"},
+}
+
+// FoundationTheme defines Foundation for Sites v6 classes for form elements
+var FoundationTheme = ThemeClasses{
+	// Common elements
+	Wrapper:      StyleOption{},
+	Label:        StyleOption{},
+	Required:     StyleOption{Style: "color: #cc4b37;"},
+	Error:        StyleOption{Class: "form-error is-visible"},
+	Description:  StyleOption{Class: "help-text"},
+	ErrorSummary: StyleOption{Class: "callout alert"},
+
+	// Input types
+	Input:           StyleOption{},
+	Select:          StyleOption{},
+	Textarea:        StyleOption{},
+	TextareaWrapper: StyleOption{},
+	Radio:           StyleOption{},
+	RadioWrapper:    StyleOption{Style: "display: inline-block;"},
+	RadioLabel:      StyleOption{},
+	RadioGroup:      StyleOption{},
+	Checkbox:        StyleOption{},
+	CheckboxWrapper: StyleOption{},
+	CheckboxLabel:   StyleOption{},
+	Range:           StyleOption{},
+	RangeWrapper:    StyleOption{},
+	RangeValue:      StyleOption{Class: "help-text"},
+	Color:           StyleOption{},
+	Button:          StyleOption{Class: "button"},
+	Cancel:          StyleOption{Class: "hollow button secondary"},
+	File:            StyleOption{},
+	Multicheckbox:   StyleOption{},
+
+	// Form container
+	Form:        StyleOption{Class: "callout"},
+	FormGroup:   StyleOption{Class: "card"},
+	FormHeader:  StyleOption{Class: "card-divider"},
+	FormLegend:  StyleOption{Style: "margin: 0;"},
+	FormBody:    StyleOption{Class: "card-section"},
+	FormButtons: StyleOption{Class: "button-group"},
+
+	// Input groups
+	InputGroup:     StyleOption{Class: "input-group"},
+	InputGroupText: StyleOption{Class: "input-group-label"},
+
+	// Repeaters
+	Repeater:       StyleOption{},
+	RepeaterRow:    StyleOption{Class: "callout small"},
+	RepeaterAdd:    StyleOption{Class: "hollow button small"},
+	RepeaterRemove: StyleOption{Class: "hollow button small alert"},
+}
+
+// builtinThemes maps the names of the built-in themes to their classes.
+var builtinThemes = map[string]ThemeClasses{
+	"bootstrap":  BootstrapTheme,
+	"tailwind":   TailwindTheme,
+	"tailwindv4": TailwindV4Theme,
+	"plain":      PlainTheme,
+	"bulma":      BulmaTheme,
+	"pico":       PicoTheme,
+	"foundation": FoundationTheme,
+}
+
+// NewBuiltinTheme returns a new, unregistered copy of one of the built-in themes
+// (see InitThemes for the names) with its templates loaded. Use it to customize a
+// built-in theme for one form without affecting the global registry.
+func NewBuiltinTheme(name string) (*Theme, error) {
+	classes, ok := builtinThemes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown built-in theme %q", name)
 	}
-	if theme, ok := GetTheme("tailwind"); ok {
-		if err := theme.LoadTemplatesFS(TemplateFS, "gohtml"); err != nil {
-			return err
-		}
+	theme := NewTheme(name, classes, nil)
+	if err := theme.loadBuiltinTemplates(); err != nil {
+		return nil, err
+	}
+	return theme, nil
+}
+
+// loadBuiltinTemplates loads the shared templates and, on top of them, the theme's
+// own templates in overlays/<name>, if any.
+func (t *Theme) loadBuiltinTemplates() error {
+	tmpl := template.New("").Funcs(t.funcMap())
+	if err := parseTemplatesFS(tmpl, TemplateFS, "gohtml"); err != nil {
+		return err
 	}
-	if theme, ok := GetTheme("tailwindv4"); ok {
-		if err := theme.LoadTemplatesFS(TemplateFS, "gohtml"); err != nil {
+	overlay := path.Join("overlays", t.Name)
+	if _, err := fs.Stat(overlayFS, overlay); err == nil {
+		if err := parseTemplatesFS(tmpl, overlayFS, overlay); err != nil {
 			return err
 		}
 	}
-	if theme, ok := GetTheme("plain"); ok {
-		if err := theme.LoadTemplatesFS(TemplateFS, "gohtml"); err != nil {
+	t.setTemplates(tmpl)
+	return nil
+}
+
+// InitThemes registers the built-in themes: "bootstrap", "tailwind", "tailwindv4",
+// "plain", "bulma", "pico" and "foundation". A theme that is already registered under
+// one of these names is kept, so InitThemes can be called more than once.
+func InitThemes() error {
+	for name := range builtinThemes {
+		if err := registerBuiltinTheme(name); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// registerBuiltinTheme registers the built-in theme called name unless a theme with
+// that name is already registered.
+func registerBuiltinTheme(name string) error {
+	if _, ok := GetTheme(name); ok {
+		return nil
+	}
+	theme := NewTheme(name, builtinThemes[name], nil)
+	if err := theme.loadBuiltinTemplates(); err != nil {
+		return fmt.Errorf("theme %q: %w", name, err)
+	}
+	themeCache.Lock()
+	if _, ok := themeCache.themes[name]; !ok {
+		themeCache.themes[name] = theme
+	}
+	themeCache.Unlock()
+	return nil
+}
@@ -18,7 +18,7 @@ func TestInitThemes_LoadsEmbeddedTemplates(t *testing.T) {
 		t.Fatalf("InitThemes: %v", err)
 	}
 
-	for _, name := range []string{"bootstrap", "tailwind", "tailwindv4", "plain"} {
+	for _, name := range []string{"bootstrap", "tailwind", "tailwindv4", "plain", "bulma", "pico", "foundation"} {
 		theme, ok := templates.GetTheme(name)
 		if !ok {
 			t.Fatalf("theme %q not registered", name)
@@ -52,3 +52,21 @@ func TestInitThemes_LoadsEmbeddedTemplates(t *testing.T) {
 		}
 	}
 }
+
+func TestInitThemes_KeepsRegisteredThemes(t *testing.T) {
+	if err := templates.InitThemes(); err != nil {
+		t.Fatalf("InitThemes: %v", err)
+	}
+	theme, _ := templates.GetTheme("bootstrap")
+	if err := theme.AddTemplate("init-twice-widget", `<x-widget></x-widget>`); err != nil {
+		t.Fatalf("AddTemplate: %v", err)
+	}
+
+	if err := templates.InitThemes(); err != nil {
+		t.Fatalf("InitThemes: %v", err)
+	}
+	again, _ := templates.GetTheme("bootstrap")
+	if again != theme || !again.HasTemplate("init-twice-widget") {
+		t.Fatal("expected InitThemes to keep the registered bootstrap theme")
+	}
+}
@@ -0,0 +1,192 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// BootstrapDarkVariant switches the Bootstrap theme to dark surfaces ("bootstrap:dark").
+var BootstrapDarkVariant = ThemeClasses{
+	Label:       StyleOption{Class: "form-label small mb-1 text-light"},
+	Description: StyleOption{Class: "form-text small text-white-50"},
+
+	Input:         StyleOption{Class: "form-control bg-dark text-light border-secondary"},
+	Select:        StyleOption{Class: "form-select form-select-sm bg-dark text-light border-secondary"},
+	Textarea:      StyleOption{Class: "form-control form-control-sm bg-dark text-light border-secondary"},
+	Radio:         StyleOption{Class: "form-check-input bg-dark border-secondary"},
+	RadioLabel:    StyleOption{Class: "form-check-label text-light"},
+	Checkbox:      StyleOption{Class: "form-check-input bg-dark border-secondary"},
+	CheckboxLabel: StyleOption{Class: "form-check-label text-light"},
+	RangeValue:    StyleOption{Class: "small text-white-50"},
+	Cancel:        StyleOption{Class: "btn btn-outline-light btn-sm"},
+	File:          StyleOption{Class: "form-control form-control-sm bg-dark text-light border-secondary"},
+
+	Form:       StyleOption{Class: "mx-auto border border-secondary rounded shadow-sm p-3 bg-dark text-light"},
+	FormGroup:  StyleOption{Class: "card card-sm mb-2 text-bg-dark border-secondary"},
+	FormHeader: StyleOption{Class: "card-header py-1 border-secondary"},
+
+	InputGroupText: StyleOption{Class: "input-group-text bg-secondary text-light border-secondary"},
+
+	RepeaterRow: StyleOption{Class: "border border-secondary rounded p-2 mb-2"},
+}
+
+// BootstrapCompactVariant tightens spacing and control sizes ("bootstrap:compact").
+var BootstrapCompactVariant = ThemeClasses{
+	Wrapper: StyleOption{Class: "mb-1"},
+	Label:   StyleOption{Class: "form-label small mb-0"},
+
+	Input:  StyleOption{Class: "form-control form-control-sm"},
+	Button: StyleOption{Class: "btn btn-primary btn-sm py-0"},
+	Cancel: StyleOption{Class: "btn btn-outline-secondary btn-sm py-0"},
+
+	Form:        StyleOption{Class: "mx-auto border rounded p-2"},
+	FormGroup:   StyleOption{Class: "card mb-1"},
+	FormHeader:  StyleOption{Class: "card-header py-0 px-2"},
+	FormBody:    StyleOption{Class: "card-body py-1 px-2"},
+	FormButtons: StyleOption{Class: "d-grid gap-1 mt-2"},
+
+	InputGroup: StyleOption{Class: "input-group input-group-sm"},
+
+	RepeaterRow: StyleOption{Class: "border rounded p-1 mb-1"},
+}
+
+// TailwindDarkVariant switches the Tailwind v3 theme to dark surfaces ("tailwind:dark").
+var TailwindDarkVariant = ThemeClasses{
+	Label:        StyleOption{Class: "block text-sm font-medium leading-6 text-gray-100"},
+	Required:     StyleOption{Class: "text-red-400"},
+	Error:        StyleOption{Class: "mt-1 text-sm text-red-400"},
+	Description:  StyleOption{Class: "mt-1 text-sm text-gray-400"},
+	ErrorSummary: StyleOption{Class: "mb-4 rounded-md border border-red-900 bg-red-950 p-4 text-sm text-red-300"},
+
+	Input:         StyleOption{Class: "block w-full rounded-md border border-gray-700 bg-gray-800 px-3 py-1.5 text-gray-100 shadow-sm placeholder:text-gray-500 focus:ring-2 focus:ring-inset focus:ring-indigo-500 sm:text-sm sm:leading-6"},
+	Select:        StyleOption{Class: "block w-full rounded-md border border-gray-700 bg-gray-800 px-3 py-1.5 text-gray-100 shadow-sm focus:ring-2 focus:ring-inset focus:ring-indigo-500 sm:text-sm sm:leading-6"},
+	Textarea:      StyleOption{Class: "block w-full rounded-md border border-gray-700 bg-gray-800 px-3 py-1.5 text-gray-100 shadow-sm placeholder:text-gray-500 focus:ring-2 focus:ring-inset focus:ring-indigo-500 sm:text-sm sm:leading-6"},
+	RadioLabel:    StyleOption{Class: "ml-2 text-sm text-gray-100"},
+	CheckboxLabel: StyleOption{Class: "ml-2 text-sm text-gray-100"},
+	RangeValue:    StyleOption{Class: "text-sm text-gray-400"},
+	Cancel:        StyleOption{Class: "rounded-md bg-gray-800 px-3 py-2 text-sm font-semibold text-gray-100 shadow-sm ring-1 ring-inset ring-gray-700 hover:bg-gray-700"},
+	File:          StyleOption{Class: "block w-full rounded-md border border-gray-700 bg-gray-800 px-3 py-1.5 text-gray-100 shadow-sm sm:text-sm"},
+
+	Form:       StyleOption{Class: "mx-auto max-w-md rounded-lg border border-gray-700 bg-gray-900 p-4 shadow-sm"},
+	FormGroup:  StyleOption{Class: "mb-2 rounded-lg border border-gray-700 bg-gray-900"},
+	FormHeader: StyleOption{Class: "border-b border-gray-700 bg-gray-800 px-4 py-2"},
+	FormLegend: StyleOption{Class: "text-sm font-semibold text-gray-100"},
+
+	InputGroupText: StyleOption{Class: "inline-flex items-center rounded-l-md border border-r-0 border-gray-700 bg-gray-800 px-3 text-gray-400 text-sm"},
+
+	RepeaterRow: StyleOption{Class: "mb-2 rounded-md border border-gray-700 p-3"},
+}
+
+// TailwindCompactVariant tightens spacing and control sizes ("tailwind:compact").
+var TailwindCompactVariant = ThemeClasses{
+	Wrapper: StyleOption{Class: "mb-1"},
+	Label:   StyleOption{Class: "block text-xs font-medium text-gray-900"},
+
+	Input:    StyleOption{Class: "border border-gray-200 block w-full rounded px-2 py-1 text-sm text-gray-900 shadow-sm placeholder:text-gray-400 focus:ring-1 focus:ring-indigo-600"},
+	Select:   StyleOption{Class: "border border-gray-200 block w-full rounded px-2 py-1 text-sm text-gray-900 shadow-sm focus:ring-1 focus:ring-indigo-600"},
+	Textarea: StyleOption{Class: "border border-gray-200 block w-full rounded px-2 py-1 text-sm text-gray-900 shadow-sm placeholder:text-gray-400 focus:ring-1 focus:ring-indigo-600"},
+	Button:   StyleOption{Class: "rounded bg-indigo-600 px-2 py-1 text-xs font-semibold text-white hover:bg-indigo-500 disabled:opacity-50 disabled:cursor-not-allowed"},
+
+	Form:        StyleOption{Class: "mx-auto max-w-md rounded border border-gray-200 bg-white p-2"},
+	FormGroup:   StyleOption{Class: "mb-1 rounded border border-gray-200 bg-white"},
+	FormHeader:  StyleOption{Class: "border-b border-gray-200 bg-gray-50 px-2 py-1"},
+	FormBody:    StyleOption{Class: "p-2"},
+	FormButtons: StyleOption{Class: "mt-2 flex justify-end"},
+
+	RepeaterRow: StyleOption{Class: "mb-1 rounded border border-gray-200 p-2"},
+}
+
+// variantRegistry holds the class overrides of every variant, keyed by "base:variant".
+var variantRegistry = struct {
+	sync.RWMutex
+	variants map[string]ThemeClasses
+}{
+	variants: map[string]ThemeClasses{
+		"bootstrap:dark":    BootstrapDarkVariant,
+		"bootstrap:compact": BootstrapCompactVariant,
+		"tailwind:dark":     TailwindDarkVariant,
+		"tailwind:compact":  TailwindCompactVariant,
+	},
+}
+
+// RegisterVariant registers a variant of the theme called base, such as "dark". The
+// variant is selected as "base:variant" and renders with the base theme's templates
+// and classes, with every StyleOption set in classes replaced. It applies to every
+// theme called base, including themes a form owns through form.WithTheme. Register
+// variants at startup, before they are first used.
+func RegisterVariant(base, variant string, classes ThemeClasses) {
+	variantRegistry.Lock()
+	defer variantRegistry.Unlock()
+	variantRegistry.variants[base+":"+variant] = classes
+}
+
+// Variant returns the registered variant called name of t. Variants are derived from
+// t once and then reused; they pick up template changes of t, such as hot reloads, on
+// the next Reload. Variants of variants ("bootstrap:compact:dark") apply the
+// overrides in order.
+func (t *Theme) Variant(name string) (*Theme, error) {
+	t.execMu.RLock()
+	v := t.variants[name]
+	t.execMu.RUnlock()
+	if v != nil {
+		return v, nil
+	}
+
+	base, _, _ := strings.Cut(t.Name, ":")
+	variantRegistry.RLock()
+	classes, ok := variantRegistry.variants[base+":"+name]
+	variantRegistry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown variant %q of theme %q", name, t.Name)
+	}
+
+	t.execMu.RLock()
+	parentTemplates := t.Templates
+	t.execMu.RUnlock()
+	v, err := t.Extend(t.Name+":"+name, nil, classes)
+	if err != nil {
+		return nil, err
+	}
+	v.parent = t
+	v.parentTemplates = parentTemplates
+
+	t.execMu.Lock()
+	defer t.execMu.Unlock()
+	if existing := t.variants[name]; existing != nil {
+		return existing, nil
+	}
+	if t.variants == nil {
+		t.variants = make(map[string]*Theme)
+	}
+	t.variants[name] = v
+	return v, nil
+}
+
+// syncParent re-derives the templates of a variant when those of its parent changed.
+func (t *Theme) syncParent() error {
+	p := t.parent
+	p.execMu.RLock()
+	tmpl := p.Templates
+	p.execMu.RUnlock()
+
+	t.execMu.RLock()
+	current := t.parentTemplates
+	t.execMu.RUnlock()
+	if tmpl == current {
+		return nil
+	}
+
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	clone.Funcs(t.funcMap())
+
+	t.execMu.Lock()
+	defer t.execMu.Unlock()
+	t.Templates = clone
+	t.parentTemplates = tmpl
+	t.exec = nil
+	return nil
+}
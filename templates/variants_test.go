@@ -0,0 +1,53 @@
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/donseba/go-form/v2/templates"
+)
+
+func TestThemeVariant(t *testing.T) {
+	base, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dark, err := base.Variant("dark")
+	if err != nil {
+		t.Fatalf("Variant: %v", err)
+	}
+	if dark.Name != "bootstrap:dark" {
+		t.Errorf("unexpected name %q", dark.Name)
+	}
+	if dark.Classes.Input != templates.BootstrapDarkVariant.Input || dark.Classes.Wrapper != templates.BootstrapTheme.Wrapper {
+		t.Errorf("unexpected classes: %+v", dark.Classes)
+	}
+	if again, _ := base.Variant("dark"); again != dark {
+		t.Error("variant should be derived once")
+	}
+	if base.Classes.Input != templates.BootstrapTheme.Input {
+		t.Error("base theme classes changed")
+	}
+
+	// Variants stack, later overrides win.
+	compactDark, err := dark.Variant("compact")
+	if err != nil {
+		t.Fatalf("stacked variant: %v", err)
+	}
+	if compactDark.Name != "bootstrap:dark:compact" || compactDark.Classes.Input != templates.BootstrapCompactVariant.Input || compactDark.Classes.Select != templates.BootstrapDarkVariant.Select {
+		t.Errorf("unexpected stacked variant %q: %+v", compactDark.Name, compactDark.Classes)
+	}
+
+	if _, err := base.Variant("sepia"); err == nil {
+		t.Error("expected error for unknown variant")
+	}
+
+	templates.RegisterVariant("bootstrap", "brand", templates.ThemeClasses{Button: templates.StyleOption{Class: "btn btn-brand"}})
+	brand, err := base.Variant("brand")
+	if err != nil {
+		t.Fatalf("registered variant: %v", err)
+	}
+	if brand.Classes.Button.Class != "btn btn-brand" {
+		t.Errorf("unexpected button class %q", brand.Classes.Button.Class)
+	}
+}
@@ -0,0 +1,73 @@
+package form
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/templates"
+)
+
+func TestFormOwnedThemes(t *testing.T) {
+	type Simple struct {
+		Info
+		Name string `form:"input,text" label:"Name"`
+	}
+	model := Simple{Info: Info{Target: "/"}}
+
+	base, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantA, err := base.Extend("bootstrap", nil, templates.ThemeClasses{Input: templates.StyleOption{Class: "tenant-a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantB, err := base.Extend("bootstrap", nil, templates.ThemeClasses{Input: templates.StyleOption{Class: "tenant-b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fa := New(WithTheme(tenantA))
+	fb := New(WithTheme(tenantB))
+	global := NewForm()
+
+	render := func(f *Form) string {
+		t.Helper()
+		html, err := f.formRender(model, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(html)
+	}
+
+	if out := render(fa); !strings.Contains(out, "tenant-a") || strings.Contains(out, "tenant-b") {
+		t.Errorf("form A should use tenant A classes: %s", out)
+	}
+	if out := render(fb); !strings.Contains(out, "tenant-b") || strings.Contains(out, "tenant-a") {
+		t.Errorf("form B should use tenant B classes: %s", out)
+	}
+	if out := render(global); strings.Contains(out, "tenant-") {
+		t.Errorf("global bootstrap theme picked up tenant classes: %s", out)
+	}
+
+	// Names not in the form's registry fall back to the global themes.
+	fa.SetTheme("plain")
+	if out := render(fa); strings.Contains(out, "tenant-a") {
+		t.Errorf("expected global plain theme: %s", out)
+	}
+
+	empty := New(WithTheme(templates.NewTheme("empty", templates.ThemeClasses{}, nil)))
+	if _, err := empty.formRender(model, nil); err == nil {
+		t.Error("expected error for theme without templates")
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	f := New(WithTranslation(func(loc Localizer, key string, args ...any) string { return "x" }))
+	if !f.translationEnabled || f.themeName != "bootstrap" || f.validators == nil || !f.HasCSRFStore() {
+		t.Errorf("unexpected form: %+v", f)
+	}
+	if New(WithCSRFStore(nil)).HasCSRFStore() {
+		t.Error("expected CSRF store to be replaced")
+	}
+}
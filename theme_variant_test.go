@@ -0,0 +1,101 @@
+package form
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/templates"
+)
+
+type variantForm struct {
+	Info
+	Name string `form:"input,text" label:"Name"`
+}
+
+func TestFormRender_VariantOption(t *testing.T) {
+	f := NewForm()
+	f.SetTheme("bootstrap")
+	model := variantForm{Info: Info{Target: "/"}}
+
+	tmpl := template.Must(template.New("page").Funcs(f.FuncMap()).Parse(`{{ form_render .Form nil "variant" .Variant }}`))
+	render := func(variant string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, map[string]any{"Form": model, "Variant": variant}); err != nil {
+			t.Fatalf("variant %q: %v", variant, err)
+		}
+		return buf.String()
+	}
+
+	if out := render("dark"); !strings.Contains(out, templates.BootstrapDarkVariant.Input.Class) {
+		t.Errorf("expected dark input classes: %s", out)
+	}
+	// Options the variant does not override come from the base theme.
+	if out := render("dark"); !strings.Contains(out, templates.BootstrapTheme.Button.Class) {
+		t.Errorf("expected base button classes: %s", out)
+	}
+	if out := render(""); strings.Contains(out, "bg-dark") {
+		t.Errorf("empty variant should render the base theme: %s", out)
+	}
+
+	// Selecting the variant as the form's theme works as well.
+	f.SetTheme("tailwind:compact")
+	html, err := f.formRender(model, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), templates.TailwindCompactVariant.Input.Class) {
+		t.Errorf("expected compact input classes: %s", html)
+	}
+}
+
+func TestFormRender_VariantOptionErrors(t *testing.T) {
+	f := NewForm()
+	model := variantForm{Info: Info{Target: "/"}}
+
+	for name, kv := range map[string][]any{
+		"odd":             {"variant"},
+		"unknown key":     {"varient", "dark"},
+		"non-string":      {"variant", 1},
+		"unknown variant": {"variant", "sepia"},
+	} {
+		if _, err := f.formRender(model, nil, kv...); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFormRender_VariantOfFormTheme(t *testing.T) {
+	base, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := base.AddTemplate("wrapper", `<div class="tenant">{{ .Control }}</div>`); err != nil {
+		t.Fatal(err)
+	}
+	f := New(WithTheme(base))
+	model := variantForm{Info: Info{Target: "/"}}
+
+	html, err := f.formRender(model, nil, "variant", "dark")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(html)
+	if !strings.Contains(out, `class="tenant"`) || !strings.Contains(out, templates.BootstrapDarkVariant.Input.Class) {
+		t.Errorf("expected the form's theme with dark classes: %s", out)
+	}
+
+	// Template changes of the base reach the variant on the next render.
+	if err := base.AddTemplate("wrapper", `<div class="tenant-v2">{{ .Control }}</div>`); err != nil {
+		t.Fatal(err)
+	}
+	html, err = f.formRender(model, nil, "variant", "dark")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), `class="tenant-v2"`) {
+		t.Errorf("variant did not follow the base theme: %s", html)
+	}
+}
@@ -3,7 +3,9 @@ package form
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,6 +35,11 @@ const (
 	tagDisabled = "disabled"
 	// Enable translation support for enum values
 	tagTranslate = "translate"
+	// File upload restrictions
+	tagAccept   = "accept"
+	tagMaxFiles = "maxFiles"
+	// Render the control with a named theme template
+	tagTemplate = "template"
 )
 
 var (
@@ -69,9 +76,19 @@ var (
 
 type Transformer struct {
 	Fields []types.FormField `json:"fields"`
+
+	// rootDepth is the number of namespace segments in front of every name.
+	rootDepth int
 }
 
 func NewTransformer(model interface{}) (*Transformer, error) {
+	return NewTransformerWithNamespace(model, "")
+}
+
+// NewTransformerWithNamespace is NewTransformer with every field id and name placed
+// under namespace, e.g. "billing" turns Street into billing.Street. Bind the submitted
+// form with MapForm(r, &dst, namespace+".").
+func NewTransformerWithNamespace(model interface{}, namespace string) (*Transformer, error) {
 	var renderInfo *Info
 	switch wrapped := model.(type) {
 	case RenderModel:
@@ -105,8 +122,12 @@ func NewTransformer(model interface{}) (*Transformer, error) {
 		return nil, fmt.Errorf("form model must be a struct, got %s", modelValue.Kind())
 	}
 
-	tr := &Transformer{}
-	fields, err := tr.scanModel(modelValue, modelType)
+	var names []string
+	if namespace != "" {
+		names = strings.Split(namespace, ".")
+	}
+	tr := &Transformer{rootDepth: len(names)}
+	fields, err := tr.scanModel(modelValue, modelType, names...)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +138,15 @@ func NewTransformer(model interface{}) (*Transformer, error) {
 
 	tr.Fields = collapseStructRadioGroups(fields)
 
+	// Files can only be submitted with a multipart body.
+	if hasFileField(tr.Fields) {
+		for i := range tr.Fields {
+			if tr.Fields[i].Type == types.FieldTypeForm {
+				tr.Fields[i].Enctype = "multipart/form-data"
+			}
+		}
+	}
+
 	return tr, nil
 }
 
@@ -162,114 +192,43 @@ func (t *Transformer) JSON() json.RawMessage {
 func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names ...string) ([]types.FormField, error) {
 	var fields []types.FormField
 
+	ti := cachedTypeInfo(rType)
+
 	// Check for form metadata
-	if rType.NumField() > 0 && rType.Field(0).Anonymous && rType.Field(0).Type == reflect.TypeOf(Info{}) {
+	if ti.hasInfo {
 		info := rValue.Field(0).Interface().(Info)
 		fields = append(fields, formFieldFromInfo(info))
 	}
 
-	for i := 0; i < rType.NumField(); i++ {
+	for i := range ti.fields {
 		// Skip the Info field as we've already processed it
-		if i == 0 && rType.Field(i).Anonymous && rType.Field(i).Type == reflect.TypeOf(Info{}) {
+		if i == 0 && ti.hasInfo {
 			continue
 		}
 
-		tags := rType.Field(i).Tag
-
-		name := tags.Get(tagName)
-		fieldName := rType.Field(i).Name
-		if name == "" {
-			name = fieldName
-		}
+		fi := &ti.fields[i]
+		name := fi.name
+		fieldName := fi.field.Name
 
 		nname := append(names, name)
 		field := types.FormField{
 			Id:          strings.Join(nname, "."),
-			Label:       tags.Get(tagLabel),
-			Placeholder: tags.Get(tagPlaceholder),
-			Description: tags.Get(tagDescription),
+			Label:       fi.label,
+			Placeholder: fi.placeholder,
+			Description: fi.description,
 			Name:        strings.Join(nname, "."),
-			Class:       tags.Get(tagClass),
+			Class:       fi.class,
 			Value:       rValue.Field(i).Interface(),
+			GroupBefore: fi.groupBefore,
+			GroupAfter:  fi.groupAfter,
+			Type:        fi.formType,
+			InputType:   fi.inputType,
+			Required:    fi.required,
+			Disabled:    fi.disabled,
+			Widget:      fi.widget,
 		}
-
-		// Process data attributes if present
-		if dataTag := tags.Get(tagData); dataTag != "" {
-			field.Data = make(map[string]string)
-
-			// Improved parser for data attributes that handles complex values
-			var i, start int
-			var inQuote bool
-			var inBrace int
-
-			for i < len(dataTag) {
-				switch dataTag[i] {
-				case '"':
-					// Toggle quote state if not escaped
-					if i == 0 || dataTag[i-1] != '\\' {
-						inQuote = !inQuote
-					}
-				case '{':
-					if !inQuote {
-						inBrace++
-					}
-				case '}':
-					if !inQuote {
-						inBrace--
-					}
-				case ',':
-					// Only split on commas that are not inside quotes or braces
-					if !inQuote && inBrace == 0 {
-						// Process the attribute
-						attr := dataTag[start:i]
-						if strings.Contains(attr, "=") {
-							parts := strings.SplitN(attr, "=", 2)
-							if len(parts) == 2 {
-								key := strings.TrimSpace(parts[0])
-								value := strings.TrimSpace(parts[1])
-								field.Data[key] = value
-							}
-						}
-						start = i + 1 // Move past the comma
-					}
-				}
-				i++
-			}
-
-			// Process the last attribute
-			if start < len(dataTag) {
-				attr := dataTag[start:]
-				if strings.Contains(attr, "=") {
-					parts := strings.SplitN(attr, "=", 2)
-					if len(parts) == 2 {
-						key := strings.TrimSpace(parts[0])
-						value := strings.TrimSpace(parts[1])
-						field.Data[key] = value
-					}
-				}
-			}
-		}
-
-		groupTag := tags.Get(tagGroup)
-		if groupTag != "" {
-			if strings.Contains(groupTag, ",") {
-				parts := strings.SplitN(groupTag, ",", 2)
-				field.GroupBefore = strings.TrimSpace(parts[0])
-				if len(parts) > 1 {
-					field.GroupAfter = strings.TrimSpace(parts[1])
-				}
-			} else {
-				field.GroupBefore = groupTag
-			}
-		}
-
-		formTag := tags.Get(tagForm)
-		if strings.Contains(formTag, ",") {
-			parts := strings.Split(formTag, ",")
-			field.Type = types.FieldType(parts[0])
-			field.InputType = types.InputFieldType(parts[1])
-		} else {
-			field.Type = types.FieldType(formTag)
+		if fi.data != nil {
+			field.Data = maps.Clone(fi.data)
 		}
 
 		// Special case: explicit declaration of a struct-based radio group.
@@ -282,27 +241,20 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 			field.Label = name
 		}
 
-		if tags.Get(tagRequired) == "true" {
-			field.Required = true
-		}
-		if tags.Get(tagDisabled) == "true" {
-			field.Disabled = true
-		}
-
 		// Check if translation is enabled: struct tag takes precedence over global default
-		tagValue := tags.Get(tagTranslate)
+		tagValue := fi.translate
 		shouldTranslate := tagValue == "true" || (tagValue != "false" && DefaultEnumTranslation)
 
-		if rType.Field(i).Type.Implements(enumType) {
-			enums := reflect.New(rType.Field(i).Type).Interface().(Enumerator).Enum()
+		if fi.isEnum {
+			enums := reflect.New(fi.field.Type).Interface().(Enumerator).Enum()
 			var fieldValue []types.FieldValue
 
 			var typeName string
 			if shouldTranslate {
-				typeName = rType.Field(i).Type.Name()
+				typeName = fi.field.Type.Name()
 				if typeName == "" {
 					// Fallback for unnamed types (aliases, pointers, etc.)
-					typeName = strings.ReplaceAll(rType.Field(i).Type.String(), ".", "_")
+					typeName = strings.ReplaceAll(fi.field.Type.String(), ".", "_")
 					typeName = strings.ReplaceAll(typeName, "*", "")
 				}
 			}
@@ -329,36 +281,24 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 			continue
 		}
 
-		if rType.Field(i).Tag.Get(tagValues) != "" {
+		if fi.values != nil {
 			// If the tag contains values, it is a dropdown or radio field
-			values := strings.Split(rType.Field(i).Tag.Get(tagValues), ";")
-			var fieldValue []types.FieldValue
-			for _, v := range values {
-				if strings.Contains(v, ":") {
-					parts := strings.SplitN(v, ":", 2)
-					if len(parts) != 2 {
-						return nil, fmt.Errorf("invalid value format in tag %s for field %s", tagValues, fieldName)
-					}
-					fieldValue = append(fieldValue, types.FieldValue{
-						Value:    strings.TrimSpace(parts[0]),
-						Name:     strings.TrimSpace(parts[1]),
-						Disabled: false,
-					})
-					continue
-				}
-
-				fieldValue = append(fieldValue, types.FieldValue{
-					Value:    v,
-					Name:     v,
-					Disabled: false,
-				})
-			}
+			fieldValue := slices.Clone(fi.values)
 
 			// Set the field type based on the form tag
 			if field.Type == types.FieldTypeRadios {
 				field.Values = fieldValue
 			} else if field.Type == types.FieldTypeDropdown {
 				field.Values = fieldValue
+			} else if field.Type == types.FieldTypeMultiCheckbox {
+				// A slice holds the checked values, e.g. []string with values:"a:A;b:B".
+				field.Values = fieldValue
+				field.ValueMap = map[string]bool{}
+				if fv := reflect.Indirect(rValue.Field(i)); fv.Kind() == reflect.Slice {
+					for j := 0; j < fv.Len(); j++ {
+						field.ValueMap[fmt.Sprint(fv.Index(j).Interface())] = true
+					}
+				}
 			} else {
 				field.Type = types.FieldTypeDropdown
 				field.Values = fieldValue
@@ -368,7 +308,7 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 			continue
 		}
 
-		if rType.Field(i).Type.Implements(mapperType) {
+		if fi.isMapper {
 			maps := rValue.Field(i).Interface().(Mapper).Mapper()
 			var fieldValue []types.FieldValue
 
@@ -391,7 +331,7 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 		}
 
 		//new addition to provide sorted key value pairs
-		if rType.Field(i).Type.Implements(sortedMapperType) {
+		if fi.isSortedMapper {
 			maps := rValue.Field(i).Interface().(SortedMapper).SortedMapper()
 			var fieldValue []types.FieldValue
 
@@ -405,7 +345,7 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 			}
 
 			// Use multicheckbox if form tag is multicheckbox, else fallback to dropdownmapped
-			if field.Type == types.FieldTypeMultiCheckbox || rType.Field(i).Tag.Get(tagForm) == "multicheckbox" {
+			if field.Type == types.FieldTypeMultiCheckbox || fi.formTag == "multicheckbox" {
 				field.Type = types.FieldTypeMultiCheckbox
 				// For checkboxes, Value should be a map[string]bool for checked state
 				valueMap := map[string]bool{}
@@ -428,10 +368,22 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 			continue
 		}
 
+		// File uploads render as a file input; their value is never echoed back.
+		if fi.isFile {
+			field.Type = types.FieldTypeInput
+			field.InputType = types.InputFieldTypeFile
+			field.Value = nil
+			field.Accept = fi.accept
+			field.Multiple = fi.field.Type != fileHeaderType && fi.maxFiles != "1"
+
+			fields = append(fields, field)
+			continue
+		}
+
 		// check if time.Time or time.Time pointer
-		if rType.Field(i).Type == reflect.TypeOf(time.Time{}) || rType.Field(i).Type == reflect.TypeOf(&time.Time{}) {
+		if fi.isTime {
 			var elem time.Time
-			if rType.Field(i).Type == reflect.TypeOf(&time.Time{}) {
+			if fi.field.Type == timePtrType {
 				if rValue.Field(i).IsNil() {
 					elem = time.Time{}
 				} else {
@@ -458,8 +410,8 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 				field.Value = elem.Format(time.DateOnly)
 			}
 
-			if tags.Get(tagStep) != "" {
-				field.Step = tags.Get(tagStep)
+			if fi.step != "" {
+				field.Step = fi.step
 			} else {
 				field.Step = "60"
 			}
@@ -469,7 +421,7 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 			continue
 		}
 
-		fType := rType.Field(i).Type
+		fType := fi.field.Type
 		fValue := rValue.Field(i)
 
 		if fValue.Kind() == reflect.Ptr && fValue.IsNil() {
@@ -484,14 +436,14 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 
 		switch fType.Kind() {
 		case reflect.String:
-			if tags.Get(tagRows) != "" {
-				field.Rows = tags.Get(tagRows)
+			if fi.rows != "" {
+				field.Rows = fi.rows
 			}
-			if tags.Get(tagCols) != "" {
-				field.Cols = tags.Get(tagCols)
+			if fi.cols != "" {
+				field.Cols = fi.cols
 			}
-			if tags.Get(tagMaxLength) != "" {
-				field.MaxLength = tags.Get(tagMaxLength)
+			if fi.maxLength != "" {
+				field.MaxLength = fi.maxLength
 			}
 
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
@@ -503,16 +455,16 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 
 			field.Type = types.FieldTypeInput
 
-			if tags.Get(tagStep) != "" {
-				field.Step = tags.Get(tagStep)
+			if fi.step != "" {
+				field.Step = fi.step
 			} else {
 				field.Step = "1"
 			}
-			if tags.Get(tagMin) != "" {
-				field.Min = tags.Get(tagMin)
+			if fi.min != "" {
+				field.Min = fi.min
 			}
-			if tags.Get(tagMax) != "" {
-				field.Max = tags.Get(tagMax)
+			if fi.max != "" {
+				field.Max = fi.max
 			}
 		case reflect.Float32, reflect.Float64:
 			if field.InputType == "" {
@@ -521,20 +473,20 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 
 			field.Type = types.FieldTypeInput
 
-			if tags.Get(tagStep) != "" {
-				field.Step = tags.Get(tagStep)
+			if fi.step != "" {
+				field.Step = fi.step
 			} else {
 				field.Step = "any"
 			}
-			if tags.Get(tagMin) != "" {
-				field.Min = tags.Get(tagMin)
+			if fi.min != "" {
+				field.Min = fi.min
 			}
-			if tags.Get(tagMax) != "" {
-				field.Max = tags.Get(tagMax)
+			if fi.max != "" {
+				field.Max = fi.max
 			}
 		case reflect.Bool:
 			fieldType := types.FieldTypeCheckbox
-			if len(names) > 0 && names[len(names)-1] == name {
+			if len(names) > t.rootDepth && names[len(names)-1] == name {
 				// radio-options use the same 'name' as their parent for grouping
 				fieldType = types.FieldTypeRadios
 				field.InputType = types.InputFieldTypeRadioStruct // Set the new input type for struct-based radio buttons
@@ -554,11 +506,46 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 					field.Value = "" // Set empty value when false
 				}
 			}
-		case reflect.Slice, reflect.Array:
+		case reflect.Slice:
+			// Slices render as a group with one row per element. Rows are named
+			// Field.<index> so MapForm can bind them back.
+			elemType := fType.Elem()
+			if sliceStructElem(elemType) == nil && !isScalarSliceElem(elemType.Kind()) {
+				break
+			}
+
+			inputType := field.InputType
+			if field.Type != types.FieldTypeRepeater || sliceStructElem(elemType) == nil {
+				field.Type = types.FieldTypeGroup
+			}
+			field.InputType = types.InputFieldTypeNone
+			field.Legend = fi.legend
+			if field.Legend == "" {
+				field.Legend = field.Label
+			}
+
+			for j := 0; j < fValue.Len(); j++ {
+				if sliceStructElem(elemType) == nil {
+					field.Fields = append(field.Fields, sliceScalarField(field, inputType, fValue.Index(j), nname, j))
+					continue
+				}
+				row, err := t.sliceRowField(field, fValue.Index(j), nname, j)
+				if err != nil {
+					return nil, err
+				}
+				field.Fields = append(field.Fields, row)
+			}
+
+			if field.Type == types.FieldTypeRepeater {
+				if err := t.repeaterField(&field, elemType, nname, fi.min, fi.max); err != nil {
+					return nil, err
+				}
+			}
+		case reflect.Array:
 		case reflect.Map:
 		case reflect.Struct:
 			field.Type = types.FieldTypeGroup
-			field.Legend = tags.Get(tagLegend)
+			field.Legend = fi.legend
 			if field.Legend == "" {
 				field.Legend = field.Label
 			}
@@ -571,7 +558,7 @@ func (t *Transformer) scanModel(rValue reflect.Value, rType reflect.Type, names
 
 			// If the struct field itself is declared as radios, treat contained bool fields
 			// as radio options and build the Values slice for the radio-group template.
-			if tags.Get(tagForm) == string(types.FieldTypeRadios) {
+			if fi.formTag == string(types.FieldTypeRadios) {
 				field.Type = types.FieldTypeRadios
 				field.InputType = types.InputFieldTypeRadioStruct
 				for _, sub := range field.Fields {
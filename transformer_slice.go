@@ -0,0 +1,140 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+// sliceStructElem returns the struct type held by a slice element type (T or *T),
+// or nil when the elements are not structs. time.Time does not count as a struct.
+func sliceStructElem(elemType reflect.Type) reflect.Type {
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct || elemType == reflect.TypeOf(time.Time{}) {
+		return nil
+	}
+	return elemType
+}
+
+// isScalarSliceElem reports whether a slice of kind k binds one form value per element.
+// []byte is deliberately excluded.
+func isScalarSliceElem(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// sliceNames returns a copy of names with the element index appended, so rows never
+// share a backing array with their parent.
+func sliceNames(names []string, index int) []string {
+	out := make([]string, 0, len(names)+1)
+	out = append(out, names...)
+	return append(out, strconv.Itoa(index))
+}
+
+// sliceRowField renders one struct element of a slice as a group whose fields are
+// named Parent.<index>.Field, which MapForm binds back into the slice.
+func (t *Transformer) sliceRowField(parent types.FormField, elem reflect.Value, names []string, index int) (types.FormField, error) {
+	if elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			elem = reflect.New(elem.Type().Elem())
+		}
+		elem = elem.Elem()
+	}
+
+	rowNames := sliceNames(names, index)
+	row := types.FormField{
+		Type:   types.FieldTypeGroup,
+		Id:     strings.Join(rowNames, "."),
+		Name:   strings.Join(rowNames, "."),
+		Label:  fmt.Sprintf("%s %d", parent.Label, index+1),
+		Legend: fmt.Sprintf("%s %d", parent.Legend, index+1),
+		Value:  elem.Interface(),
+	}
+
+	var err error
+	row.Fields, err = t.scanModel(elem, elem.Type(), rowNames...)
+	if err != nil {
+		return types.FormField{}, err
+	}
+	return row, nil
+}
+
+// sliceScalarField renders one element of a slice of strings, numbers or bools.
+func sliceScalarField(parent types.FormField, inputType types.InputFieldType, elem reflect.Value, names []string, index int) types.FormField {
+	rowNames := sliceNames(names, index)
+	field := types.FormField{
+		Type:        types.FieldTypeInput,
+		InputType:   inputType,
+		Id:          strings.Join(rowNames, "."),
+		Name:        strings.Join(rowNames, "."),
+		Label:       fmt.Sprintf("%s %d", parent.Label, index+1),
+		Placeholder: parent.Placeholder,
+		Class:       parent.Class,
+		Disabled:    parent.Disabled,
+		Value:       elem.Interface(),
+	}
+
+	switch elem.Kind() {
+	case reflect.Bool:
+		field.Type = types.FieldTypeCheckbox
+		field.InputType = types.InputFieldTypeNone
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.InputType == "" {
+			field.InputType = types.InputFieldTypeNumber
+		}
+		field.Step = "1"
+	case reflect.Float32, reflect.Float64:
+		if field.InputType == "" {
+			field.InputType = types.InputFieldTypeNumber
+		}
+		field.Step = "any"
+	default:
+		if field.InputType == "" {
+			field.InputType = types.InputFieldTypeText
+		}
+	}
+	return field
+}
+
+// repeaterIndexPlaceholder stands in for the row index in a repeater's prototype row.
+// The repeater template replaces it when a row is added in the browser.
+const repeaterIndexPlaceholder = "__index__"
+
+// repeaterField pads a repeater to its minimum number of rows and builds the blank
+// prototype row used by the theme's repeater template.
+func (t *Transformer) repeaterField(field *types.FormField, elemType reflect.Type, names []string, minRows, maxRows string) error {
+	field.Min = minRows
+	field.Max = maxRows
+
+	if n, err := strconv.Atoi(minRows); err == nil {
+		for j := len(field.Fields); j < n; j++ {
+			row, err := t.sliceRowField(*field, reflect.New(elemType).Elem(), names, j)
+			if err != nil {
+				return err
+			}
+			field.Fields = append(field.Fields, row)
+		}
+	}
+
+	structType := sliceStructElem(elemType)
+	protoNames := make([]string, 0, len(names)+1)
+	protoNames = append(protoNames, names...)
+	protoNames = append(protoNames, repeaterIndexPlaceholder)
+
+	var err error
+	field.Prototype, err = t.scanModel(reflect.New(structType).Elem(), structType, protoNames...)
+	return err
+}
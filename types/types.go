@@ -25,6 +25,13 @@ type FieldValue struct {
 	Translate bool   `json:"translate,omitempty"`
 }
 
+// ErrorSummaryItem is one error in the summary at the top of a form
+type ErrorSummaryItem struct {
+	Id      string // id of the element to link to, empty for errors about the whole form
+	Label   string // label of the field, a translation key
+	Message string
+}
+
 // FormField represents a form field
 type FormField struct {
 	Type         FieldType         `json:"type"`
@@ -57,6 +64,11 @@ type FormField struct {
 	Class        string            `json:"class,omitempty"`
 	Data         map[string]string `json:"data,omitempty"` // Data attributes
 	ValueMap     map[string]bool   `json:"valueMap,omitempty"`
+	Accept       string            `json:"accept,omitempty"`    // Accepted file types for file inputs
+	Multiple     bool              `json:"multiple,omitempty"`  // Allow selecting more than one file
+	Enctype      string            `json:"enctype,omitempty"`   // Form encoding, set when the form has file inputs
+	Prototype    []FormField       `json:"prototype,omitempty"` // Blank row of a repeater, indexed with a placeholder
+	Widget       string            `json:"widget,omitempty"`    // Theme template that renders the control instead of the default
 }
 
 // Constants for field types
@@ -75,6 +87,7 @@ const (
 	FieldTypeForm           FieldType = "form"
 	FieldTypeInputGroup     FieldType = "inputgroup"
 	FieldTypeMultiCheckbox  FieldType = "multicheckbox"
+	FieldTypeRepeater       FieldType = "repeater"
 )
 
 // Constants for input types
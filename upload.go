@@ -0,0 +1,172 @@
+package form
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+// DefaultMultipartMemory is the maxMemory passed to http.Request.ParseMultipartForm
+// when MapForm needs to read uploaded files from a request that was not parsed yet.
+var DefaultMultipartMemory int64 = 32 << 20
+
+// FileUpload holds every file posted under a single field name. Together with
+// *multipart.FileHeader and []*multipart.FileHeader it can be used for fields
+// tagged `form:"input,file"`.
+type FileUpload struct {
+	Files []*multipart.FileHeader
+}
+
+// First returns the first uploaded file, or nil when nothing was uploaded.
+func (u FileUpload) First() *multipart.FileHeader {
+	if len(u.Files) == 0 {
+		return nil
+	}
+	return u.Files[0]
+}
+
+// Empty reports whether no file was uploaded.
+func (u FileUpload) Empty() bool {
+	return len(u.Files) == 0
+}
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+	fileUploadType      = reflect.TypeOf(FileUpload{})
+)
+
+// isFileType reports whether t is one of the types MapForm binds uploaded files to.
+func isFileType(t reflect.Type) bool {
+	return t == fileHeaderType || t == fileHeaderSliceType || t == fileUploadType
+}
+
+// hasFileField reports whether any field, including nested ones, is a file input.
+func hasFileField(fields []types.FormField) bool {
+	for _, field := range fields {
+		if field.Type == types.FieldTypeInput && field.InputType == types.InputFieldTypeFile {
+			return true
+		}
+		if hasFileField(field.Fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapFileField sets fv from the files posted under key.
+func mapFileField(r *http.Request, fv reflect.Value, key string) {
+	if r == nil {
+		return
+	}
+	if r.MultipartForm == nil {
+		// Not every caller parses the request up front; ErrNotMultipart simply
+		// means there are no files to bind.
+		_ = r.ParseMultipartForm(DefaultMultipartMemory)
+	}
+	if r.MultipartForm == nil {
+		return
+	}
+
+	files := r.MultipartForm.File[key]
+	if len(files) == 0 {
+		return
+	}
+
+	switch fv.Type() {
+	case fileHeaderType:
+		fv.Set(reflect.ValueOf(files[0]))
+	case fileHeaderSliceType:
+		fv.Set(reflect.ValueOf(files))
+	case fileUploadType:
+		fv.Set(reflect.ValueOf(FileUpload{Files: files}))
+	}
+}
+
+// uploadedFiles returns the files held by value. ok is false when value is not a file type.
+func uploadedFiles(value reflect.Value) (files []*multipart.FileHeader, ok bool) {
+	if !value.IsValid() {
+		return nil, false
+	}
+	switch value.Type() {
+	case fileHeaderType:
+		if value.IsNil() {
+			return nil, true
+		}
+		return []*multipart.FileHeader{value.Interface().(*multipart.FileHeader)}, true
+	case fileHeaderSliceType:
+		return value.Interface().([]*multipart.FileHeader), true
+	case fileUploadType:
+		return value.Interface().(FileUpload).Files, true
+	}
+	return nil, false
+}
+
+// parseByteSize parses sizes such as "512", "200KB", "5MB" or "1GB" (1024 based).
+func parseByteSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"G", 1 << 30},
+		{"M", 1 << 20},
+		{"K", 1 << 10},
+		{"B", 1},
+	}
+
+	mult := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			mult = u.mult
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	return n * mult, nil
+}
+
+// acceptsFile matches a file against an HTML accept list such as ".pdf,image/*,text/csv".
+// The content type is the one reported by the client, so it is a convenience check and
+// not a substitute for inspecting the file contents.
+func acceptsFile(accept string, fh *multipart.FileHeader) bool {
+	mediaType, _, _ := mime.ParseMediaType(fh.Header.Get("Content-Type"))
+	mediaType = strings.ToLower(mediaType)
+	ext := strings.ToLower(filepath.Ext(fh.Filename))
+
+	for _, a := range strings.Split(accept, ",") {
+		a = strings.ToLower(strings.TrimSpace(a))
+		switch {
+		case a == "":
+			continue
+		case strings.HasPrefix(a, "."):
+			if ext == a {
+				return true
+			}
+		case strings.HasSuffix(a, "/*"):
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*")) {
+				return true
+			}
+		default:
+			if mediaType == a {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package form
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/types"
+)
+
+type uploadForm struct {
+	Info
+	Title       string                  `form:"input,text" label:"Title"`
+	Avatar      *multipart.FileHeader   `form:"input,file" label:"Avatar" accept:"image/*" maxSize:"1KB"`
+	Attachments []*multipart.FileHeader `form:"input,file" label:"Attachments" maxFiles:"2"`
+	Documents   FileUpload              `form:"input,file" label:"Documents" accept:".pdf"`
+}
+
+type testFile struct {
+	field       string
+	name        string
+	contentType string
+	content     string
+}
+
+func newMultipartRequest(t *testing.T, values map[string]string, files []testFile) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range values {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="`+f.field+`"; filename="`+f.name+`"`)
+		h.Set("Content-Type", f.contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestMapFormFileUploads(t *testing.T) {
+	r := newMultipartRequest(t, map[string]string{"Title": "Report"}, []testFile{
+		{field: "Avatar", name: "me.png", contentType: "image/png", content: "png"},
+		{field: "Attachments", name: "a.txt", contentType: "text/plain", content: "a"},
+		{field: "Attachments", name: "b.txt", contentType: "text/plain", content: "b"},
+		{field: "Documents", name: "doc.pdf", contentType: "application/pdf", content: "pdf"},
+	})
+
+	var s uploadForm
+	if err := MapForm(r, &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s.Title != "Report" {
+		t.Errorf("expected Title 'Report', got '%s'", s.Title)
+	}
+	if s.Avatar == nil || s.Avatar.Filename != "me.png" {
+		t.Errorf("expected Avatar me.png, got %#v", s.Avatar)
+	}
+	if len(s.Attachments) != 2 || s.Attachments[1].Filename != "b.txt" {
+		t.Errorf("expected two attachments, got %#v", s.Attachments)
+	}
+	if s.Documents.First() == nil || s.Documents.First().Filename != "doc.pdf" {
+		t.Errorf("expected Documents doc.pdf, got %#v", s.Documents)
+	}
+}
+
+func TestValidateFormFileRestrictions(t *testing.T) {
+	r := newMultipartRequest(t, nil, []testFile{
+		{field: "Avatar", name: "me.gif", contentType: "text/plain", content: strings.Repeat("x", 2048)},
+		{field: "Attachments", name: "a.txt", contentType: "text/plain", content: "a"},
+		{field: "Attachments", name: "b.txt", contentType: "text/plain", content: "b"},
+		{field: "Attachments", name: "c.txt", contentType: "text/plain", content: "c"},
+		{field: "Documents", name: "doc.docx", contentType: "application/pdf", content: "doc"},
+	})
+
+	var s uploadForm
+	if err := MapForm(r, &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	errs := NewForm().ValidateForm(&s)
+	got := scanError(errs)
+	if len(got["Avatar"]) != 2 {
+		t.Errorf("expected size and type errors for Avatar, got %v", got["Avatar"])
+	}
+	if len(got["Attachments"]) != 1 {
+		t.Errorf("expected maxFiles error for Attachments, got %v", got["Attachments"])
+	}
+	if len(got["Documents"]) != 1 {
+		t.Errorf("expected type error for Documents, got %v", got["Documents"])
+	}
+}
+
+func TestTransformerFileFieldsSetMultipartEnctype(t *testing.T) {
+	tr, err := NewTransformer(uploadForm{Info: Info{Target: "/upload", Method: "post"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tr.Fields[0].Type != types.FieldTypeForm || tr.Fields[0].Enctype != "multipart/form-data" {
+		t.Fatalf("expected multipart form field, got %#v", tr.Fields[0])
+	}
+	for _, field := range tr.Fields[2:] {
+		if field.Type != types.FieldTypeInput || field.InputType != types.InputFieldTypeFile {
+			t.Fatalf("expected file input for %s, got %s/%s", field.Name, field.Type, field.InputType)
+		}
+	}
+	if tr.Fields[2].Multiple || !tr.Fields[3].Multiple || !tr.Fields[4].Multiple {
+		t.Errorf("unexpected multiple flags: %v %v %v", tr.Fields[2].Multiple, tr.Fields[3].Multiple, tr.Fields[4].Multiple)
+	}
+
+	f := NewForm()
+	html, err := f.formRender(uploadForm{Info: Info{Target: "/upload", Method: "post"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), `enctype="multipart/form-data"`) {
+		t.Errorf("rendered form is missing multipart enctype: %s", html)
+	}
+	if !strings.Contains(string(html), `accept="image/*"`) {
+		t.Errorf("rendered file input is missing accept attribute: %s", html)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{"512": 512, "2KB": 2048, "5mb": 5 << 20, "1G": 1 << 30}
+	for in, want := range cases {
+		got, err := parseByteSize(in)
+		if err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	if _, err := parseByteSize("lots"); err == nil {
+		t.Error("expected error for invalid size")
+	}
+}
+
+func TestCheckValidateTagsFileLimits(t *testing.T) {
+	type badUpload struct {
+		Avatar      *multipart.FileHeader   `form:"input,file" maxSize:"big"`
+		Attachments []*multipart.FileHeader `form:"input,file" maxFiles:"two"`
+	}
+
+	err := NewForm().CheckValidateTags(badUpload{})
+	if err == nil {
+		t.Fatal("expected errors for the file limit tags")
+	}
+	for _, want := range []string{`Avatar: invalid maxSize tag "big"`, `Attachments: invalid maxFiles tag "two"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+
+	if err := NewForm().CheckValidateTags(uploadForm{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
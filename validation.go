@@ -1,14 +1,16 @@
 package form
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 	"unicode/utf8"
+
+	"github.com/donseba/go-form/v2/types"
 )
 
 var (
@@ -32,6 +34,19 @@ var (
 	TranslationKeySuffix                        = "form||Value should end with '%s'"
 	TranslationKeyContains                      = "form||Value should contain '%s'"
 	TranslationKeyStep                          = "form||Value should be a multiple of %f"
+	TranslationKeyEqField                       = "form||Value should match %s"
+	TranslationKeyNeField                       = "form||Value should not match %s"
+	TranslationKeyGtField                       = "form||Value should be greater than %s"
+	TranslationKeyGteField                      = "form||Value should be greater than or equal to %s"
+	TranslationKeyLtField                       = "form||Value should be less than %s"
+	TranslationKeyLteField                      = "form||Value should be less than or equal to %s"
+	TranslationKeyBindNumber                    = "form||Value '%s' is not a number"
+	TranslationKeyBindBool                      = "form||Value '%s' is not a valid yes or no value"
+	TranslationKeyBindTime                      = "form||Value '%s' is not a valid date or time"
+	TranslationKeyBindUUID                      = "form||Value '%s' is not a valid UUID"
+	TranslationKeyFileType                      = "form||File '%s' is not an accepted file type"
+	TranslationKeyFileSize                      = "form||File should not be larger than %s"
+	TranslationKeyMaxFiles                      = "form||No more than %d files may be uploaded"
 	TranslationKeyCSRFTokenMissing              = "form||CSRF token is missing"
 	TranslationKeyCSRFTokenInvalid              = "form||Invalid CSRF token"
 	TranslationKeyCSRFTokenError                = "form||Error processing CSRF token"
@@ -58,6 +73,23 @@ func (e FieldValidationError) FieldError() (field, err string) {
 	return e.Field, e.Err
 }
 
+// FormError is an error about the form as a whole rather than one of its fields, such
+// as an expired CSRF token. It is listed in the error summary at the top of the form,
+// like a FieldValidationError with an empty Field.
+type FormError struct {
+	Err string
+}
+
+// Error implements the error interface for FormError.
+func (e FormError) Error() string {
+	return e.Err
+}
+
+// FieldError returns an empty field and the error message.
+func (e FormError) FieldError() (field, err string) {
+	return "", e.Err
+}
+
 // Helper functions for each validation type
 func validateRequired(f *Form, field reflect.StructField, value reflect.Value, loc Localizer, getErr func(string, any) string) (errs FieldErrors) {
 	req := field.Tag.Get("required")
@@ -130,12 +162,9 @@ func validateLength(f *Form, field reflect.StructField, value reflect.Value, loc
 	return
 }
 
-func validatePattern(f *Form, field reflect.StructField, value reflect.Value, loc Localizer, getErr func(string, any) string) (errs FieldErrors) {
-	if pattern := field.Tag.Get("pattern"); pattern != "" && value.Kind() == reflect.String {
-		matched, err := regexp.MatchString(pattern, value.String())
-		if err == nil && !matched {
-			errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(TranslationKeyPattern, pattern)})
-		}
+func validatePattern(fi *fieldInfo, value reflect.Value, getErr func(string, any) string) (errs FieldErrors) {
+	if fi.pattern != nil && value.Kind() == reflect.String && !fi.pattern.MatchString(value.String()) {
+		errs = append(errs, FieldValidationError{Field: fi.field.Name, Err: getErr(TranslationKeyPattern, fi.pattern.String())})
 	}
 	return
 }
@@ -173,13 +202,23 @@ func validateZero(f *Form, field reflect.StructField, value reflect.Value, loc L
 
 func validateSliceArrayLength(f *Form, field reflect.StructField, value reflect.Value, loc Localizer, getErr func(string, any) string) (errs FieldErrors) {
 	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
-		if minItems := field.Tag.Get("minItems"); minItems != "" {
+		minItems, maxItems := field.Tag.Get("minItems"), field.Tag.Get("maxItems")
+		// Repeaters declare their row bounds with min and max.
+		if field.Tag.Get("form") == string(types.FieldTypeRepeater) {
+			if minItems == "" {
+				minItems = field.Tag.Get("min")
+			}
+			if maxItems == "" {
+				maxItems = field.Tag.Get("max")
+			}
+		}
+		if minItems != "" {
 			minCount, err := strconv.Atoi(minItems)
 			if err == nil && value.Len() < minCount {
 				errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(TranslationKeyMinItems, minCount)})
 			}
 		}
-		if maxItems := field.Tag.Get("maxItems"); maxItems != "" {
+		if maxItems != "" {
 			maxCount, err := strconv.Atoi(maxItems)
 			if err == nil && value.Len() > maxCount {
 				errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(TranslationKeyMaxItems, maxCount)})
@@ -212,6 +251,13 @@ func validatePrefixSuffixContains(f *Form, field reflect.StructField, value refl
 }
 
 func validateValues(f *Form, field reflect.StructField, value reflect.Value, loc Localizer, getErr func(string, any) string) (errs FieldErrors) {
+	if vals := field.Tag.Get("values"); vals != "" && value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String {
+		// Multiple selected values, e.g. a []string rendered as a multicheckbox.
+		for j := 0; j < value.Len(); j++ {
+			errs = append(errs, validateValues(f, field, value.Index(j), loc, getErr)...)
+		}
+		return
+	}
 	if vals := field.Tag.Get("values"); vals != "" && value.Kind() == reflect.String {
 		allowed := map[string]struct{}{}
 		for _, v := range strings.Split(vals, ";") {
@@ -318,6 +364,38 @@ func validateSortedMapper(f *Form, field reflect.StructField, value reflect.Valu
 	return
 }
 
+func validateFile(f *Form, field reflect.StructField, value reflect.Value, loc Localizer, getErr func(string, any) string) (errs FieldErrors) {
+	files, ok := uploadedFiles(value)
+	if !ok || len(files) == 0 {
+		return
+	}
+	if maxFiles := field.Tag.Get("maxFiles"); maxFiles != "" {
+		maxCount, err := strconv.Atoi(maxFiles)
+		if err == nil && len(files) > maxCount {
+			errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(TranslationKeyMaxFiles, maxCount)})
+		}
+	}
+	if maxSize := field.Tag.Get("maxSize"); maxSize != "" {
+		limit, err := parseByteSize(maxSize)
+		if err == nil {
+			for _, fh := range files {
+				if fh.Size > limit {
+					errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(TranslationKeyFileSize, maxSize)})
+					break
+				}
+			}
+		}
+	}
+	if accept := field.Tag.Get("accept"); accept != "" {
+		for _, fh := range files {
+			if !acceptsFile(accept, fh) {
+				errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(TranslationKeyFileType, fh.Filename)})
+			}
+		}
+	}
+	return
+}
+
 // internalFormValidation validates struct fields based on struct tags.
 // Returns FieldErrors a slice or FieldError.
 func (f *Form) internalFormValidation(form any, loc Localizer) FieldErrors {
@@ -326,9 +404,10 @@ func (f *Form) internalFormValidation(form any, loc Localizer) FieldErrors {
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
-	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
+	ti := cachedTypeInfo(v.Type())
+	for i := range ti.fields {
+		fi := &ti.fields[i]
+		field := fi.field
 		value := v.Field(i)
 
 		// Custom error message
@@ -349,7 +428,7 @@ func (f *Form) internalFormValidation(form any, loc Localizer) FieldErrors {
 		errList = append(errList,
 			validateLength(f, field, value, loc, getErr)...)
 		errList = append(errList,
-			validatePattern(f, field, value, loc, getErr)...)
+			validatePattern(fi, value, getErr)...)
 		errList = append(errList,
 			validateURL(f, field, value, loc, getErr)...)
 		errList = append(errList,
@@ -370,6 +449,10 @@ func (f *Form) internalFormValidation(form any, loc Localizer) FieldErrors {
 			validateMapper(f, field, value, loc, getErr)...)
 		errList = append(errList,
 			validateSortedMapper(f, field, value, loc, getErr)...)
+		errList = append(errList,
+			validateFile(f, field, value, loc, getErr)...)
+		errList = append(errList,
+			validateCrossField(f, v, field, value, getErr)...)
 	}
 	return errList
 }
@@ -395,19 +478,38 @@ func (f *Form) ValidateForm(form any) FieldErrors {
 }
 
 func (f *Form) ValidateFormLocalized(form any, loc Localizer) FieldErrors {
+	return f.ValidateFormContext(context.Background(), form, loc)
+}
+
+// ValidateFormContext validates form like ValidateFormLocalized and passes ctx on to
+// validators registered with RegisterValidationMethodCtx and to StructValidator models.
+func (f *Form) ValidateFormContext(ctx context.Context, form any, loc Localizer) FieldErrors {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if loc == nil {
+		loc = &DefaultLocalizer{}
+	}
+	return f.validateForm(ctx, form, loc, "")
+}
+
+// validateForm validates one struct level. path is the dotted path of the struct
+// within the root model ("" for the root, "Address." for a nested struct).
+func (f *Form) validateForm(ctx context.Context, form any, loc Localizer, path string) FieldErrors {
 	errList := f.internalFormValidation(form, loc) // built-in validations
 
 	v := reflect.ValueOf(form)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
-	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
+	ti := cachedTypeInfo(v.Type())
+	for i := range ti.fields {
+		fi := &ti.fields[i]
+		field := fi.field
 		value := v.Field(i)
-		// Handle nested structs (excluding time.Time)
-		if value.Kind() == reflect.Struct && field.Type.PkgPath() != "time" {
-			nestedErrs := f.ValidateFormLocalized(value.Addr().Interface(), loc)
+		// Handle nested structs (excluding time.Time and file uploads)
+		if value.Kind() == reflect.Struct && field.Type.PkgPath() != "time" && !fi.isFile {
+			nestedErrs := f.validateForm(ctx, value.Addr().Interface(), loc, path+field.Name+".")
 			for _, err := range nestedErrs {
 				f, e := err.FieldError()
 				errList = append(errList, FieldValidationError{
@@ -417,8 +519,8 @@ func (f *Form) ValidateFormLocalized(form any, loc Localizer) FieldErrors {
 			}
 			continue
 		}
-		if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct && field.Type.Elem().PkgPath() != "time" {
-			nestedErrs := f.ValidateFormLocalized(value.Interface(), loc)
+		if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct && field.Type.Elem().PkgPath() != "time" && !fi.isFile {
+			nestedErrs := f.validateForm(ctx, value.Interface(), loc, path+field.Name+".")
 			for _, err := range nestedErrs {
 				f, e := err.FieldError()
 
@@ -429,23 +531,64 @@ func (f *Form) ValidateFormLocalized(form any, loc Localizer) FieldErrors {
 			}
 			continue
 		}
-		validateTag := field.Tag.Get("validate")
-		if validateTag == "" {
-			continue
+		// Validate every struct element of a slice; errors are reported as Field.<index>.Sub.
+		if value.Kind() == reflect.Slice && sliceStructElem(field.Type.Elem()) != nil {
+			for j := 0; j < value.Len(); j++ {
+				elem := value.Index(j)
+				if elem.Kind() == reflect.Ptr {
+					if elem.IsNil() {
+						continue
+					}
+				} else {
+					elem = elem.Addr()
+				}
+				elemPath := path + field.Name + "." + strconv.Itoa(j) + "."
+				nestedErrs := f.validateForm(ctx, elem.Interface(), loc, elemPath)
+				for _, err := range nestedErrs {
+					f, e := err.FieldError()
+					errList = append(errList, FieldValidationError{
+						Field: field.Name + "." + strconv.Itoa(j) + "." + f,
+						Err:   e,
+					})
+				}
+			}
 		}
-		for _, validatorName := range strings.Split(validateTag, ",") {
-			validatorName = strings.TrimSpace(validatorName)
-			if validatorName == "" {
+		// Unusable entries are skipped here and returned by CheckValidateTags, Bind and
+		// the renderers; the other validators of the field still run.
+		for _, call := range fi.validate {
+			if fn, ok := f.ctxValidators[call.Name]; ok {
+				errList = append(errList, fn(ctx, FieldContext{
+					Value:     value.Interface(),
+					Field:     field,
+					Path:      path + field.Name,
+					Parent:    parentInterface(v),
+					Localizer: loc,
+					Args:      call.Args,
+				})...)
 				continue
 			}
-			if fn, ok := f.validators[validatorName]; ok {
+			if fn, ok := f.validators[call.Name]; ok && len(call.Args) == 0 {
 				errList = append(errList, fn(value.Interface(), field)...)
 			}
 		}
 	}
+
+	// Struct-level rules run after all tag based checks.
+	if sv, ok := structValidator(form, v); ok {
+		errList = append(errList, sv.Validate(ctx)...)
+	}
 	return errList
 }
 
+// parentInterface returns the struct as a pointer when possible so validators can
+// type-assert it to the model type they expect.
+func parentInterface(v reflect.Value) any {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	return v.Interface()
+}
+
 func isEmptyValue(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.String:
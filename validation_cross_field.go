@@ -0,0 +1,227 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StructValidator is implemented by models that need validation rules spanning
+// several fields. ValidateFormLocalized calls Validate after the tag based checks;
+// nested structs are validated too and their errors prefixed with the field name.
+type StructValidator interface {
+	Validate(ctx context.Context) FieldErrors
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// validateCrossField handles tags that compare a field with its siblings in parent:
+// eqfield, nefield, gtfield, gtefield, ltfield, ltefield, required_if, required_with
+// and required_without.
+func validateCrossField(f *Form, parent reflect.Value, field reflect.StructField, value reflect.Value, getErr func(string, any) string) (errs FieldErrors) {
+	addErr := func(key string, arg any) {
+		errs = append(errs, FieldValidationError{Field: field.Name, Err: getErr(key, arg)})
+	}
+
+	if isEmptyValue(value) {
+		if requiredIf := field.Tag.Get("required_if"); requiredIf != "" && siblingMatches(parent, requiredIf) {
+			addErr(TranslationKeyRequired, nil)
+			return
+		}
+		if with := field.Tag.Get("required_with"); with != "" && anySibling(parent, with, false) {
+			addErr(TranslationKeyRequired, nil)
+			return
+		}
+		if without := field.Tag.Get("required_without"); without != "" && anySibling(parent, without, true) {
+			addErr(TranslationKeyRequired, nil)
+			return
+		}
+	}
+
+	if name := field.Tag.Get("eqfield"); name != "" {
+		if other, label, ok := sibling(parent, name); ok && !valuesEqual(value, other) {
+			addErr(TranslationKeyEqField, label)
+		}
+	}
+	if name := field.Tag.Get("nefield"); name != "" {
+		if other, label, ok := sibling(parent, name); ok && valuesEqual(value, other) {
+			addErr(TranslationKeyNeField, label)
+		}
+	}
+
+	orderings := []struct {
+		tag   string
+		key   string
+		valid func(cmp int) bool
+	}{
+		{"gtfield", TranslationKeyGtField, func(cmp int) bool { return cmp > 0 }},
+		{"gtefield", TranslationKeyGteField, func(cmp int) bool { return cmp >= 0 }},
+		{"ltfield", TranslationKeyLtField, func(cmp int) bool { return cmp < 0 }},
+		{"ltefield", TranslationKeyLteField, func(cmp int) bool { return cmp <= 0 }},
+	}
+	for _, o := range orderings {
+		name := field.Tag.Get(o.tag)
+		if name == "" {
+			continue
+		}
+		other, label, ok := sibling(parent, name)
+		// Empty values are left to the required checks.
+		if !ok || isEmptyValue(value) || isEmptyValue(other) {
+			continue
+		}
+		if cmp, ok := compareValues(value, other); ok && !o.valid(cmp) {
+			addErr(o.key, label)
+		}
+	}
+	return
+}
+
+// crossFieldTags are the tags that name a sibling field.
+var crossFieldTags = []string{
+	"eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield",
+	"required_if", "required_with", "required_without",
+}
+
+// crossFieldNames returns the sibling field names in the value of a cross-field tag.
+func crossFieldNames(key, tag string) ([]string, error) {
+	switch key {
+	case "required_if":
+		var names []string
+		for _, cond := range strings.Fields(tag) {
+			name, _, found := strings.Cut(cond, "=")
+			if !found {
+				return nil, fmt.Errorf("condition %q is not of the form Field=value", cond)
+			}
+			names = append(names, name)
+		}
+		return names, nil
+	case "required_with", "required_without":
+		var names []string
+		for _, name := range strings.Split(tag, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+		return names, nil
+	}
+	return []string{tag}, nil
+}
+
+// sibling returns the named field of parent and the label used in messages.
+func sibling(parent reflect.Value, name string) (reflect.Value, string, bool) {
+	sf, ok := parent.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, "", false
+	}
+	label := sf.Tag.Get("label")
+	if label == "" {
+		label = sf.Name
+	}
+	return parent.FieldByIndex(sf.Index), label, true
+}
+
+// siblingMatches evaluates a required_if condition such as "Country=NL" or
+// "Country=NL|BE". Several conditions separated by spaces must all hold.
+func siblingMatches(parent reflect.Value, conditions string) bool {
+	for _, cond := range strings.Fields(conditions) {
+		name, want, found := strings.Cut(cond, "=")
+		if !found {
+			return false
+		}
+		other, _, ok := sibling(parent, name)
+		if !ok {
+			return false
+		}
+		// A nil pointer holds no value, so it matches nothing.
+		other = reflect.Indirect(other)
+		if !other.IsValid() {
+			return false
+		}
+		got := fmt.Sprint(other.Interface())
+		matched := false
+		for _, w := range strings.Split(want, "|") {
+			if got == w {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// anySibling reports whether any of the comma separated fields is empty (wantEmpty)
+// or filled in (!wantEmpty).
+func anySibling(parent reflect.Value, names string, wantEmpty bool) bool {
+	for _, name := range strings.Split(names, ",") {
+		other, _, ok := sibling(parent, strings.TrimSpace(name))
+		if ok && isEmptyValue(other) == wantEmpty {
+			return true
+		}
+	}
+	return false
+}
+
+func valuesEqual(a, b reflect.Value) bool {
+	if cmp, ok := compareValues(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// compareValues orders numbers, strings and time.Time values. ok is false when a and b
+// cannot be compared, for example a number and a string.
+func compareValues(a, b reflect.Value) (cmp int, ok bool) {
+	a, b = reflect.Indirect(a), reflect.Indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), true
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+
+	af, aok := numericValue(a)
+	bf, bok := numericValue(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	switch {
+	case af < bf:
+		return -1, true
+	case af > bf:
+		return 1, true
+	}
+	return 0, true
+}
+
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// structValidator returns the StructValidator implemented by the model, checking the
+// pointer receiver as well when v is addressable.
+func structValidator(form any, v reflect.Value) (StructValidator, bool) {
+	if sv, ok := form.(StructValidator); ok {
+		return sv, true
+	}
+	if v.CanAddr() {
+		if sv, ok := v.Addr().Interface().(StructValidator); ok {
+			return sv, true
+		}
+	}
+	return nil, false
+}
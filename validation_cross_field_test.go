@@ -0,0 +1,146 @@
+package form
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type signupForm struct {
+	Password string    `form:"input,password" label:"Password"`
+	Confirm  string    `form:"input,password" label:"Confirm" eqfield:"Password"`
+	Start    time.Time `form:"input,date" label:"Start"`
+	End      time.Time `form:"input,date" label:"End" gtfield:"Start"`
+	Country  string    `form:"input,text" label:"Country"`
+	VAT      string    `form:"input,text" label:"VAT number" required_if:"Country=NL|BE"`
+	Email    string    `form:"input,email" label:"Email"`
+	Phone    string    `form:"input,tel" label:"Phone" required_without:"Email"`
+	Street   string    `form:"input,text" label:"Street"`
+	City     string    `form:"input,text" label:"City" required_with:"Street"`
+}
+
+func TestValidateForm_CrossField(t *testing.T) {
+	day := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	valid := signupForm{
+		Password: "secret", Confirm: "secret",
+		Start: day, End: day.AddDate(0, 0, 1),
+		Country: "DE", Email: "a@example.com",
+	}
+
+	cases := []struct {
+		name   string
+		modify func(*signupForm)
+		field  string
+	}{
+		{"valid", func(*signupForm) {}, ""},
+		{"password mismatch", func(s *signupForm) { s.Confirm = "other" }, "Confirm"},
+		{"end before start", func(s *signupForm) { s.End = day.AddDate(0, 0, -1) }, "End"},
+		{"end equals start", func(s *signupForm) { s.End = day }, "End"},
+		{"vat required for NL", func(s *signupForm) { s.Country = "NL" }, "VAT"},
+		{"vat required for BE", func(s *signupForm) { s.Country = "BE" }, "VAT"},
+		{"phone required without email", func(s *signupForm) { s.Email = "" }, "Phone"},
+		{"city required with street", func(s *signupForm) { s.Street = "Main 1" }, "City"},
+	}
+
+	f := NewForm()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			form := valid
+			c.modify(&form)
+			errList := f.ValidateForm(&form)
+			if c.field == "" {
+				if len(errList) != 0 {
+					t.Fatalf("expected no errors, got %+v", errList)
+				}
+				return
+			}
+			if len(errList) != 1 {
+				t.Fatalf("expected 1 error, got %+v", errList)
+			}
+			if field, _ := errList[0].FieldError(); field != c.field {
+				t.Errorf("expected error on %s, got %s", c.field, field)
+			}
+		})
+	}
+}
+
+func TestValidateForm_CrossFieldNilSibling(t *testing.T) {
+	type addressForm struct {
+		Country *string `form:"input,text" label:"Country"`
+		Zip     string  `form:"input,text" label:"Zip" required_if:"Country=NL"`
+	}
+
+	f := NewForm()
+	if errs := f.ValidateForm(&addressForm{}); len(errs) != 0 {
+		t.Errorf("a nil sibling should not match, got %+v", errs)
+	}
+	nl := "NL"
+	if errs := f.ValidateForm(&addressForm{Country: &nl}); len(errs) != 1 {
+		t.Errorf("expected Zip to be required, got %+v", errs)
+	}
+}
+
+func TestCheckValidateTags_CrossFieldNames(t *testing.T) {
+	type badSiblings struct {
+		Password string
+		Confirm  string `eqfield:"Pwd"`
+		Country  string
+		VAT      string `required_if:"Contry=NL"`
+		Zip      string `required_if:"Country"`
+		City     string `required_with:"Country, Street"`
+	}
+
+	f := NewForm()
+	err := f.CheckValidateTags(badSiblings{})
+	if err == nil {
+		t.Fatal("expected errors for the unknown field names")
+	}
+	for _, want := range []string{
+		`Confirm: invalid eqfield tag "Pwd": unknown field "Pwd"`,
+		`VAT: invalid required_if tag "Contry=NL": unknown field "Contry"`,
+		`Zip: invalid required_if tag "Country"`,
+		`City: invalid required_with tag "Country, Street": unknown field "Street"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in %v", want, err)
+		}
+	}
+
+	if err := f.CheckValidateTags(signupForm{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+type bookingForm struct {
+	Guests int `form:"input,number" label:"Guests"`
+	Rooms  int `form:"input,number" label:"Rooms"`
+}
+
+func (b *bookingForm) Validate(_ context.Context) FieldErrors {
+	if b.Rooms > b.Guests {
+		return FieldErrors{FieldValidationError{Field: "Rooms", Err: "more rooms than guests"}}
+	}
+	return nil
+}
+
+type bookingWrapper struct {
+	Booking bookingForm
+}
+
+func TestValidateForm_StructValidator(t *testing.T) {
+	f := NewForm()
+
+	errList := f.ValidateForm(&bookingForm{Guests: 1, Rooms: 2})
+	if len(errList) != 1 {
+		t.Fatalf("expected struct validator error, got %+v", errList)
+	}
+
+	errList = f.ValidateForm(&bookingWrapper{Booking: bookingForm{Guests: 1, Rooms: 2}})
+	if len(errList) != 1 {
+		t.Fatalf("expected nested struct validator error, got %+v", errList)
+	}
+	if field, _ := errList[0].FieldError(); field != "Booking.Rooms" {
+		t.Errorf("expected nested error on Booking.Rooms, got %s", field)
+	}
+}
@@ -0,0 +1,74 @@
+package form
+
+import (
+	"context"
+	"testing"
+)
+
+type ctxKey string
+
+type ctxAddress struct {
+	Street string `validate:"unique"`
+}
+
+type ctxUser struct {
+	Email   string `validate:"unique"`
+	Address ctxAddress
+	Items   []ctxAddress
+}
+
+func TestValidateFormContextPassesContextAndPath(t *testing.T) {
+	f := NewForm()
+	taken := map[string]bool{"a@b.c": true, "Main": true}
+
+	var paths []string
+	f.RegisterValidationMethodCtx("unique", func(ctx context.Context, fc FieldContext) FieldErrors {
+		paths = append(paths, fc.Path)
+		if ctx.Value(ctxKey("db")) != "users" {
+			t.Errorf("context not passed to validator for %s", fc.Path)
+		}
+		if fc.Localizer == nil || fc.Parent == nil {
+			t.Errorf("missing localizer or parent for %s", fc.Path)
+		}
+		if taken[fc.Value.(string)] {
+			return FieldErrors{fc.Error("already taken")}
+		}
+		return nil
+	})
+
+	u := ctxUser{Email: "a@b.c", Address: ctxAddress{Street: "Main"}, Items: []ctxAddress{{Street: "Side"}, {Street: "Main"}}}
+	ctx := context.WithValue(context.Background(), ctxKey("db"), "users")
+	errs := scanError(f.ValidateFormContext(ctx, &u, nil))
+
+	for _, field := range []string{"Email", "Address.Street", "Items.1.Street"} {
+		if len(errs[field]) != 1 {
+			t.Errorf("expected one error for %s, got %v", field, errs)
+		}
+	}
+	if len(errs["Items.0.Street"]) != 0 {
+		t.Errorf("unexpected error for Items.0.Street: %v", errs)
+	}
+
+	want := map[string]bool{"Email": true, "Address.Street": true, "Items.0.Street": true, "Items.1.Street": true}
+	for _, p := range paths {
+		if !want[p] {
+			t.Errorf("unexpected path %q", p)
+		}
+	}
+	if len(paths) != len(want) {
+		t.Errorf("expected %d validator calls, got %v", len(want), paths)
+	}
+}
+
+func TestValidateFormContextParentIsModel(t *testing.T) {
+	f := NewForm()
+	f.RegisterValidationMethodCtx("unique", func(ctx context.Context, fc FieldContext) FieldErrors {
+		if _, ok := fc.Parent.(*ctxUser); fc.Path == "Email" && !ok {
+			t.Errorf("expected *ctxUser parent, got %T", fc.Parent)
+		}
+		return nil
+	})
+	if errs := f.ValidateForm(&ctxUser{}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
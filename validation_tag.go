@@ -0,0 +1,216 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// validatorCall is one entry of a `validate` tag, e.g. between(1,10).
+type validatorCall struct {
+	Name string
+	Args []string
+}
+
+// ValidateTagError reports a struct tag that cannot be used: a `validate` tag that does
+// not parse or names a validator that is not registered, a `pattern` that does not
+// compile, a numeric tag such as `min` that is not a number, or a cross-field tag such
+// as `eqfield` that names a field the struct does not have. It is a mistake of the
+// developer rather than of the user, so CheckValidateTags, Bind and the renderers return
+// it as an error.
+type ValidateTagError struct {
+	Field string
+	Key   string // The tag key, e.g. "validate" or "pattern"
+	Tag   string // The tag value
+	Err   string
+}
+
+// Error implements the error interface for ValidateTagError.
+func (e ValidateTagError) Error() string {
+	return fmt.Sprintf("%s: invalid %s tag %q: %s", e.Field, e.Key, e.Tag, e.Err)
+}
+
+// FieldError returns the field and error message.
+func (e ValidateTagError) FieldError() (field, err string) {
+	return e.Field, e.Error()
+}
+
+// parseValidateTag splits a `validate` tag such as "required,oneof(a|b|c),between(1,10)"
+// into validator calls. Commas inside parentheses belong to the arguments, which are
+// separated by commas or pipes.
+func parseValidateTag(tag string) ([]validatorCall, error) {
+	var calls []validatorCall
+	rest := tag
+	for rest != "" {
+		entry := rest
+		depth := 0
+		end := -1
+		for i, r := range rest {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth < 0 {
+					return nil, fmt.Errorf("unexpected ')'")
+				}
+			case ',':
+				if depth == 0 && end < 0 {
+					end = i
+				}
+			}
+			if end >= 0 {
+				break
+			}
+		}
+		if end >= 0 {
+			entry, rest = rest[:end], rest[end+1:]
+		} else {
+			if depth != 0 {
+				return nil, fmt.Errorf("missing ')'")
+			}
+			rest = ""
+		}
+
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		call, err := parseValidatorCall(entry)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
+
+func parseValidatorCall(entry string) (validatorCall, error) {
+	open := strings.IndexByte(entry, '(')
+	if open < 0 {
+		if strings.ContainsAny(entry, " )") {
+			return validatorCall{}, fmt.Errorf("invalid validator name %q", entry)
+		}
+		return validatorCall{Name: entry}, nil
+	}
+
+	name := strings.TrimSpace(entry[:open])
+	if name == "" || strings.ContainsRune(name, ' ') {
+		return validatorCall{}, fmt.Errorf("invalid validator name %q", entry)
+	}
+	if !strings.HasSuffix(entry, ")") || strings.Count(entry, "(") != 1 || strings.Count(entry, ")") != 1 {
+		return validatorCall{}, fmt.Errorf("malformed arguments in %q", entry)
+	}
+
+	call := validatorCall{Name: name}
+	inner := entry[open+1 : len(entry)-1]
+	if strings.TrimSpace(inner) == "" {
+		return call, nil
+	}
+	for _, arg := range strings.FieldsFunc(inner, func(r rune) bool { return r == ',' || r == '|' }) {
+		call.Args = append(call.Args, strings.TrimSpace(arg))
+	}
+	return call, nil
+}
+
+// validatorTagErr checks the parsed `validate` tag of fi against the registered
+// validators. Parse errors are part of fi.tagErrs.
+func (f *Form) validatorTagErr(fi *fieldInfo) *ValidateTagError {
+	tagErr := func(format string, args ...any) *ValidateTagError {
+		return &ValidateTagError{Field: fi.field.Name, Key: "validate", Tag: fi.field.Tag.Get("validate"), Err: fmt.Sprintf(format, args...)}
+	}
+
+	for _, call := range fi.validate {
+		if _, ok := f.ctxValidators[call.Name]; ok {
+			continue
+		}
+		if _, ok := f.validators[call.Name]; !ok {
+			return tagErr("unknown validator %q", call.Name)
+		}
+		if len(call.Args) > 0 {
+			return tagErr("validator %q takes no arguments; register it with RegisterValidationMethodCtx", call.Name)
+		}
+	}
+	return nil
+}
+
+// CheckValidateTags reports every unusable tag in model, including nested structs and
+// slice elements. Call it once after registering validators, for example at startup or
+// in a test, to catch typos before the first request. Bind and the renderers return the
+// same errors when they first see the model type.
+func (f *Form) CheckValidateTags(model any) error {
+	t := reflect.TypeOf(model)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("form model must be a struct, got %v", t)
+	}
+
+	var errs []error
+	f.checkValidateTags(t, "", map[reflect.Type]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+// checkModelTags runs CheckValidateTags for the type of model once per Form and
+// returns the remembered result on later calls. Models that are not structs are left
+// to the transformer to report.
+func (f *Form) checkModelTags(model any) error {
+	switch wrapped := model.(type) {
+	case RenderModel:
+		model = wrapped.Model
+	case *RenderModel:
+		if wrapped != nil {
+			model = wrapped.Model
+		}
+	}
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if cached, ok := f.checkedTags.Load(t); ok {
+		err, _ := cached.(error)
+		return err
+	}
+	var errs []error
+	f.checkValidateTags(t, "", map[reflect.Type]bool{}, &errs)
+	err := errors.Join(errs...)
+	f.checkedTags.Store(t, err)
+	return err
+}
+
+func (f *Form) checkValidateTags(t reflect.Type, path string, seen map[reflect.Type]bool, errs *[]error) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+
+	ti := cachedTypeInfo(t)
+	for i := range ti.fields {
+		fi := &ti.fields[i]
+		for _, tagErr := range fi.tagErrs {
+			tagErr.Field = path + tagErr.Field
+			*errs = append(*errs, tagErr)
+		}
+		if tagErr := f.validatorTagErr(fi); tagErr != nil {
+			tagErr.Field = path + tagErr.Field
+			*errs = append(*errs, *tagErr)
+		}
+
+		ft := fi.field.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft.PkgPath() != "time" && !fi.isFile {
+			f.checkValidateTags(ft, path+fi.field.Name+".", seen, errs)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseValidateTag(t *testing.T) {
+	calls, err := parseValidateTag("required, oneof(a|b|c),between(1,10),unique(users.email),empty()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []validatorCall{
+		{Name: "required"},
+		{Name: "oneof", Args: []string{"a", "b", "c"}},
+		{Name: "between", Args: []string{"1", "10"}},
+		{Name: "unique", Args: []string{"users.email"}},
+		{Name: "empty"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got %#v, want %#v", calls, want)
+	}
+
+	for _, bad := range []string{"between(1,10", "between1,10)", "one of", "(a)", "x(a)(b)"} {
+		if _, err := parseValidateTag(bad); err == nil {
+			t.Errorf("expected parse error for %q", bad)
+		}
+	}
+}
+
+type argsForm struct {
+	Size  string `validate:"oneof(s|m|l)"`
+	Count int    `validate:"between(1,10)"`
+}
+
+func registerArgValidators(f *Form) {
+	f.RegisterValidationMethodCtx("oneof", func(ctx context.Context, fc FieldContext) FieldErrors {
+		for _, a := range fc.Args {
+			if fc.Value == a {
+				return nil
+			}
+		}
+		return FieldErrors{fc.Error("must be one of " + strings.Join(fc.Args, ", "))}
+	})
+	f.RegisterValidationMethodCtx("between", func(ctx context.Context, fc FieldContext) FieldErrors {
+		lo, _ := strconv.Atoi(fc.Args[0])
+		hi, _ := strconv.Atoi(fc.Args[1])
+		if n := fc.Value.(int); n < lo || n > hi {
+			return FieldErrors{fc.Error("out of range")}
+		}
+		return nil
+	})
+}
+
+func TestValidatorArguments(t *testing.T) {
+	f := NewForm()
+	registerArgValidators(f)
+
+	errs := scanError(f.ValidateForm(&argsForm{Size: "xl", Count: 11}))
+	if len(errs["Size"]) != 1 || errs["Size"][0] != "must be one of s, m, l" {
+		t.Errorf("unexpected Size errors: %v", errs["Size"])
+	}
+	if len(errs["Count"]) != 1 {
+		t.Errorf("unexpected Count errors: %v", errs["Count"])
+	}
+
+	if errs := f.ValidateForm(&argsForm{Size: "m", Count: 10}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+type badTagForm struct {
+	Name   string `validate:"nosuch"`
+	Nested struct {
+		Code string `validate:"between(1,2"`
+	}
+}
+
+func TestValidateTagErrorsAreReturned(t *testing.T) {
+	f := NewForm()
+
+	if errs := f.ValidateForm(&badTagForm{}); len(errs) != 0 {
+		t.Errorf("unexpected user-facing tag errors: %v", errs)
+	}
+
+	err := f.CheckValidateTags(badTagForm{})
+	var tagErr ValidateTagError
+	if !errors.As(err, &tagErr) || tagErr.Field != "Name" {
+		t.Fatalf("expected ValidateTagError for Name, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `unknown validator "nosuch"`) || !strings.Contains(err.Error(), "Nested.Code") {
+		t.Errorf("expected both tag errors, got %v", err)
+	}
+
+	var model badTagForm
+	if _, err := f.Bind(&http.Request{Form: url.Values{}}, &model); !errors.As(err, &tagErr) {
+		t.Errorf("expected Bind to return the tag error, got %v", err)
+	}
+	if err := f.Render(io.Discard, nil, WithInfo(&model, Info{Target: "/"}), nil); !errors.As(err, &tagErr) {
+		t.Errorf("expected Render to return the tag error, got %v", err)
+	}
+
+	// Registering the validator clears the remembered result.
+	f.RegisterValidationMethod("nosuch", func(any, reflect.StructField) FieldErrors { return nil })
+	if _, err := f.Bind(&http.Request{Form: url.Values{}}, &model); err == nil || strings.Contains(err.Error(), "nosuch") {
+		t.Errorf("expected only the Nested.Code error after registering, got %v", err)
+	}
+
+	f.RegisterValidationMethod("isHexColor", func(any, reflect.StructField) FieldErrors { return nil })
+	type plainArgs struct {
+		Color string `validate:"isHexColor(short)"`
+	}
+	if err := f.CheckValidateTags(&plainArgs{}); err == nil || !strings.Contains(err.Error(), "takes no arguments") {
+		t.Errorf("expected arguments error for ValidationFunc, got %v", err)
+	}
+
+	registerArgValidators(f)
+	if err := f.CheckValidateTags(&argsForm{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateForm_SkipsOnlyUnusableValidators(t *testing.T) {
+	f := NewForm()
+	f.RegisterValidationMethod("notbob", func(v any, field reflect.StructField) FieldErrors {
+		if v == "bob" {
+			return FieldErrors{FieldValidationError{Field: field.Name, Err: "must not be bob"}}
+		}
+		return nil
+	})
+	type partlyBad struct {
+		Name string `validate:"notbob,typo"`
+		Nick string `validate:"notbob(x),notbob"`
+	}
+
+	errs := scanError(f.ValidateForm(&partlyBad{Name: "bob", Nick: "bob"}))
+	if len(errs["Name"]) != 1 || errs["Name"][0] != "must not be bob" {
+		t.Errorf("expected the notbob error for Name, got %v", errs["Name"])
+	}
+	if len(errs["Nick"]) != 1 {
+		t.Errorf("expected one notbob error for Nick, got %v", errs["Nick"])
+	}
+	if err := f.CheckValidateTags(&partlyBad{}); err == nil || !strings.Contains(err.Error(), `unknown validator "typo"`) {
+		t.Errorf("expected the typo to be reported, got %v", err)
+	}
+}
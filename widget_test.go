@@ -0,0 +1,52 @@
+package form
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donseba/go-form/v2/templates"
+)
+
+type widgetForm struct {
+	Info
+	Tags  string `form:"input,text" label:"Tags" template:"tag-picker"`
+	Notes string `form:"textarea" label:"Notes" template:"missing-widget"`
+	Title string `form:"input,text" label:"Title"`
+}
+
+func TestFieldTemplateOverride(t *testing.T) {
+	theme, err := templates.NewBuiltinTheme("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := theme.AddTemplate("tag-picker", `<tag-picker name="{{ .Field.Name }}" class="{{ themeClass "input" }}" value="{{ .Field.Value }}"></tag-picker>`); err != nil {
+		t.Fatal(err)
+	}
+	if err := theme.AddTemplate("broken", `{{ .Field.Name `); err == nil {
+		t.Error("expected parse error")
+	}
+
+	tr, err := NewTransformer(widgetForm{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Fields[1].Widget != "tag-picker" {
+		t.Errorf("expected widget on field, got %q", tr.Fields[1].Widget)
+	}
+
+	f := New(WithTheme(theme))
+	html, err := f.formRender(widgetForm{Info: Info{Target: "/"}, Tags: "go,html"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(html)
+	if !strings.Contains(out, `<tag-picker name="Tags" class="form-control" value="go,html">`) {
+		t.Errorf("expected tag picker widget, got %s", out)
+	}
+	if !strings.Contains(out, `<textarea`) {
+		t.Errorf("expected default textarea for unknown widget, got %s", out)
+	}
+	if !strings.Contains(out, `id="Tags_label"`) {
+		t.Errorf("expected labels to still be rendered, got %s", out)
+	}
+}